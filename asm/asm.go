@@ -0,0 +1,318 @@
+// Package asm assembles SM83 source code written in RGBDS syntax. It
+// supports labels, local labels, EQU constants, the db, dw and ds directives
+// and expressions with the usual C operators.
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Symbols maps label and constant names to their values. Local labels are
+// stored with the global label they belong to, e.g. "main.loop".
+type Symbols map[string]uint16
+
+// Error reports a problem in the source code
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// statement is a parsed line of source code
+type statement struct {
+	line     int
+	scope    string // global label that local labels belong to
+	labels   []string
+	equ      string // name of the constant defined by EQU
+	mnemonic string // lower case
+	operands []string
+}
+
+type assembler struct {
+	origin  uint16
+	pc      uint16
+	scope   string
+	symbols Symbols
+	final   bool // the second pass, where every symbol must be defined
+	out     []byte
+}
+
+// Assemble assembles src as if it were loaded at address 0
+func Assemble(src string) ([]byte, Symbols, error) {
+	return AssembleAt(src, 0)
+}
+
+// AssembleAt assembles src as if it were loaded at origin
+func AssembleAt(src string, origin uint16) ([]byte, Symbols, error) {
+	stmts, err := parse(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a := &assembler{origin: origin, symbols: Symbols{}}
+
+	// the first pass finds the address of every label
+	if err := a.pass(stmts); err != nil {
+		return nil, nil, err
+	}
+
+	a.final = true
+	if err := a.pass(stmts); err != nil {
+		return nil, nil, err
+	}
+
+	return a.out, a.symbols, nil
+}
+
+// MustAssemble is like Assemble but panics if src has errors. It simplifies
+// writing programs in tests.
+func MustAssemble(src string) []byte {
+	b, _, err := Assemble(src)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (a *assembler) pass(stmts []statement) error {
+	a.pc = a.origin
+	a.out = a.out[:0]
+
+	for _, s := range stmts {
+		a.scope = s.scope
+		if err := a.statement(s); err != nil {
+			return &Error{Line: s.line, Msg: err.Error()}
+		}
+	}
+	return nil
+}
+
+func (a *assembler) statement(s statement) error {
+	for _, label := range s.labels {
+		if _, ok := a.symbols[label]; ok && !a.final {
+			return fmt.Errorf("symbol %q already defined", label)
+		}
+		a.symbols[label] = a.pc
+	}
+
+	if s.equ != "" {
+		v, known, err := a.eval(s.operands[0])
+		if err != nil {
+			return err
+		}
+		if known {
+			a.symbols[s.equ] = uint16(v)
+		}
+		return nil
+	}
+
+	switch s.mnemonic {
+	case "":
+		return nil
+	case "db":
+		return a.db(s.operands)
+	case "dw":
+		return a.dw(s.operands)
+	case "ds":
+		return a.ds(s.operands)
+	}
+
+	b, err := a.encode(s.mnemonic, s.operands)
+	if err != nil {
+		return err
+	}
+	a.emit(b...)
+	return nil
+}
+
+func (a *assembler) emit(b ...byte) {
+	a.out = append(a.out, b...)
+	a.pc += uint16(len(b))
+}
+
+// lookup returns the value of a symbol. Names starting with a dot refer to
+// local labels of the current scope.
+func (a *assembler) lookup(name string) (uint16, bool) {
+	if strings.HasPrefix(name, ".") {
+		name = a.scope + name
+	}
+	v, ok := a.symbols[name]
+	return v, ok
+}
+
+func (a *assembler) db(operands []string) error {
+	for _, o := range operands {
+		if len(o) >= 2 && o[0] == '"' && o[len(o)-1] == '"' {
+			a.emit([]byte(o[1 : len(o)-1])...)
+			continue
+		}
+
+		v, err := a.value(o, -128, 255)
+		if err != nil {
+			return err
+		}
+		a.emit(byte(v))
+	}
+	return nil
+}
+
+func (a *assembler) dw(operands []string) error {
+	for _, o := range operands {
+		v, err := a.value(o, -32768, 65535)
+		if err != nil {
+			return err
+		}
+		a.emit(byte(v), byte(v>>8))
+	}
+	return nil
+}
+
+func (a *assembler) ds(operands []string) error {
+	if len(operands) == 0 || len(operands) > 2 {
+		return fmt.Errorf("ds expects a size and an optional fill byte")
+	}
+
+	n, known, err := a.eval(operands[0])
+	if err != nil {
+		return err
+	}
+	if !known || n < 0 {
+		return fmt.Errorf("ds size must be a positive constant defined before use")
+	}
+
+	var fill int
+	if len(operands) == 2 {
+		fill, err = a.value(operands[1], -128, 255)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		a.emit(byte(fill))
+	}
+	return nil
+}
+
+// value evaluates expr and checks that it is within [min, max] on the final
+// pass
+func (a *assembler) value(expr string, min, max int) (int, error) {
+	v, _, err := a.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	if a.final && (v < min || v > max) {
+		return 0, fmt.Errorf("value %d of %q out of range", v, expr)
+	}
+	return v, nil
+}
+
+// parse splits src into statements
+func parse(src string) ([]statement, error) {
+	var stmts []statement
+	var scope string
+
+	for n, line := range strings.Split(src, "\n") {
+		s := statement{line: n + 1}
+		line = strings.TrimSpace(stripComment(line))
+
+		// labels end with one or two colons
+		for {
+			i := strings.Index(line, ":")
+			if i <= 0 || !isLabel(line[:i]) {
+				break
+			}
+
+			name := line[:i]
+			if strings.HasPrefix(name, ".") {
+				if scope == "" {
+					return nil, &Error{Line: s.line, Msg: fmt.Sprintf("local label %q without a global label", name)}
+				}
+				name = scope + name
+			} else {
+				scope = name
+			}
+			s.labels = append(s.labels, name)
+
+			line = strings.TrimSpace(strings.TrimLeft(line[i:], ":"))
+		}
+
+		s.scope = scope
+		if line == "" {
+			stmts = append(stmts, s)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && strings.EqualFold(fields[0], "def") && strings.EqualFold(fields[2], "equ") {
+			// DEF NAME EQU value
+			line = strings.TrimSpace(line[len(fields[0]):])
+			fields = fields[1:]
+		}
+		if len(fields) >= 2 && strings.EqualFold(fields[1], "equ") {
+			s.equ = fields[0]
+			rest := strings.TrimSpace(line[len(fields[0]):])
+			s.operands = []string{strings.TrimSpace(rest[len("equ"):])}
+			stmts = append(stmts, s)
+			continue
+		}
+
+		s.mnemonic = strings.ToLower(fields[0])
+		rest := strings.TrimSpace(line[len(fields[0]):])
+		if rest != "" {
+			s.operands = splitOperands(rest)
+		}
+		stmts = append(stmts, s)
+	}
+
+	return stmts, nil
+}
+
+func isLabel(s string) bool {
+	for i, r := range s {
+		if !isIdent(r) || i == 0 && r >= '0' && r <= '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// stripComment removes everything after a semicolon outside of quotes
+func stripComment(line string) string {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitOperands splits s at the commas that are not inside brackets,
+// parentheses or quotes
+func splitOperands(s string) []string {
+	var ops []string
+	depth, start, quoted := 0, 0, false
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '[' || r == '(':
+			depth++
+		case r == ']' || r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			ops = append(ops, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(ops, strings.TrimSpace(s[start:]))
+}
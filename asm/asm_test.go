@@ -0,0 +1,261 @@
+package asm
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/danicat/gogoboy/cpu"
+	"github.com/danicat/gogoboy/disasm"
+)
+
+func TestAssemble(t *testing.T) {
+	tbl := []struct {
+		src      string
+		expected []byte
+	}{
+		{"nop", []byte{0x00}},
+		{"ld a, $1F", []byte{0x3E, 0x1F}},
+		{"LD A, $1F", []byte{0x3E, 0x1F}},
+		{"add a, b", []byte{0x80}},
+		{"add b", []byte{0x80}},
+		{"cp $10", []byte{0xFE, 0x10}},
+		{"add hl, sp", []byte{0x39}},
+		{"ld bc, $1234", []byte{0x01, 0x34, 0x12}},
+		{"ld [$C000], a", []byte{0xEA, 0x00, 0xC0}},
+		{"ld [$0400], sp", []byte{0x08, 0x00, 0x04}},
+		{"ld a, [hl]", []byte{0x7E}},
+		{"ld (hl), b", []byte{0x70}},
+		{"ld [hl+], a", []byte{0x22}},
+		{"ld a, [hli]", []byte{0x2A}},
+		{"ld [hl-], a", []byte{0x32}},
+		{"ldd a, [hl]", []byte{0x3A}},
+		{"ldh [$FF44], a", []byte{0xE0, 0x44}},
+		{"ldh a, [$44]", []byte{0xF0, 0x44}},
+		{"ldh [c], a", []byte{0xE2}},
+		{"ld a, [$ff00+c]", []byte{0xF2}},
+		{"ld hl, sp+5", []byte{0xF8, 0x05}},
+		{"ld hl, sp-2", []byte{0xF8, 0xFE}},
+		{"add sp, -1", []byte{0xE8, 0xFF}},
+		{"jp hl", []byte{0xE9}},
+		{"jp $0150", []byte{0xC3, 0x50, 0x01}},
+		{"jp nz, $0150", []byte{0xC2, 0x50, 0x01}},
+		{"call c, $4000", []byte{0xDC, 0x00, 0x40}},
+		{"jr @", []byte{0x18, 0xFE}},
+		{"ret nc", []byte{0xD0}},
+		{"rst $38", []byte{0xFF}},
+		{"rst 8", []byte{0xCF}},
+		{"bit 7, h", []byte{0xCB, 0x7C}},
+		{"set 0, [hl]", []byte{0xCB, 0xC6}},
+		{"swap a", []byte{0xCB, 0x37}},
+		{"stop", []byte{0x10, 0x00}},
+		{"ld a, -1", []byte{0x3E, 0xFF}},
+		{"ld a, (2 + 3) * 4", []byte{0x3E, 0x14}},
+		{"ld a, %1010_0101", []byte{0x3E, 0xA5}},
+		{"ld a, 'A'", []byte{0x3E, 0x41}},
+		{"ld hl, $1234 >> 8 | 1 << 12", []byte{0x21, 0x12, 0x10}},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.src, func(t *testing.T) {
+			b, _, err := Assemble(tc.src)
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if !bytes.Equal(b, tc.expected) {
+				t.Errorf("expected % X, got % X", tc.expected, b)
+			}
+		})
+	}
+}
+
+func TestAssembleLabels(t *testing.T) {
+	src := `
+main:
+	ld a, 5
+.loop:              ; main.loop
+	dec a
+	jr nz, .loop
+	call done
+	jr .end
+.end:
+	halt
+
+done:: ret
+`
+	b, symbols, err := AssembleAt(src, 0x0100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := []byte{
+		0x3E, 0x05,
+		0x3D,
+		0x20, 0xFD,
+		0xCD, 0x0B, 0x01,
+		0x18, 0x00,
+		0x76,
+		0xC9,
+	}
+	if !bytes.Equal(b, expected) {
+		t.Errorf("expected % X, got % X", expected, b)
+	}
+
+	for name, addr := range map[string]uint16{"main": 0x0100, "main.loop": 0x0102, "main.end": 0x010A, "done": 0x010B} {
+		if symbols[name] != addr {
+			t.Errorf("expected %s=%04X, got %04X", name, addr, symbols[name])
+		}
+	}
+}
+
+func TestAssembleDirectives(t *testing.T) {
+	src := `
+SIZE equ 3
+DEF FILL EQU $AA
+	db 1, 2, "Hi", -1
+	dw $1234, table
+	ds SIZE, FILL
+table:
+	db LOW(1)
+`
+	_, _, err := Assemble(src)
+	if err == nil {
+		t.Fatalf("expected an error for the unknown function LOW")
+	}
+
+	src = strings.Replace(src, "LOW(1)", "table & $FF", 1)
+	b, symbols, err := Assemble(src)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := []byte{0x01, 0x02, 'H', 'i', 0xFF, 0x34, 0x12, 0x0C, 0x00, 0xAA, 0xAA, 0xAA, 0x0C}
+	if !bytes.Equal(b, expected) {
+		t.Errorf("expected % X, got % X", expected, b)
+	}
+	if symbols["SIZE"] != 3 || symbols["FILL"] != 0xAA {
+		t.Errorf("expected constants SIZE=3 and FILL=AA, got %v", symbols)
+	}
+}
+
+func TestAssembleDef(t *testing.T) {
+	tbl := []struct {
+		src   string
+		name  string
+		value uint16
+	}{
+		{"DEF E EQU 5", "E", 5},
+		{"def de equ 1", "de", 1},
+		{"Def FILL Equ $AA", "FILL", 0xAA},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.src, func(t *testing.T) {
+			_, symbols, err := Assemble(tc.src)
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if v, ok := symbols[tc.name]; !ok || v != tc.value {
+				t.Errorf("expected %s=%X, got %v", tc.name, tc.value, symbols)
+			}
+		})
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tbl := []struct {
+		name string
+		src  string
+		line int
+		msg  string
+	}{
+		{"unknown instruction", "nop\nfoo a", 2, "unknown instruction"},
+		{"invalid operands", "ld a, [bc], b", 1, "invalid operands"},
+		{"no such register pair", "push ix", 1, "invalid operands"},
+		{"undefined symbol", "nop\nnop\njp nowhere", 3, "undefined symbol"},
+		{"duplicate label", "a1:\na1:", 2, "already defined"},
+		{"value out of range", "ld a, 256", 1, "out of range"},
+		{"jump out of range", "jr @ + 200", 1, "out of range"},
+		{"not high page", "ldh a, [$C000]", 1, "high page"},
+		{"local label without scope", ".loop: nop", 1, "without a global label"},
+		{"bad expression", "ld a, (1 + ", 1, "expression"},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Assemble(tc.src)
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("expected Error, got: %v", err)
+			}
+			if e.Line != tc.line {
+				t.Errorf("expected line %d, got %d", tc.line, e.Line)
+			}
+			if !strings.Contains(e.Msg, tc.msg) {
+				t.Errorf("expected message with %q, got %q", tc.msg, e.Msg)
+			}
+		})
+	}
+}
+
+func TestMustAssemble(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustAssemble to panic")
+		}
+	}()
+	MustAssemble("ld a, nowhere")
+}
+
+// TestRoundTrip disassembles every opcode in RGBDS syntax and assembles it
+// back
+func TestRoundTrip(t *testing.T) {
+	check := func(t *testing.T, code []byte) {
+		var buf bytes.Buffer
+		err := disasm.Write(&buf, disasm.Disassemble(code, 0x0100), disasm.Options{Format: disasm.RGBDS})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+
+		b, _, err := AssembleAt(buf.String(), 0x0100)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %s", strings.TrimSpace(buf.String()), err)
+		}
+		if !bytes.Equal(b, code) {
+			t.Errorf("%s: expected % X, got % X", strings.TrimSpace(buf.String()), code, b)
+		}
+	}
+
+	for _, info := range cpu.OpcodeTable {
+		if info.Mnemonic == "PREFIX" {
+			continue
+		}
+
+		code := append([]byte{info.Opcode}, 0x34, 0x92)[:info.Length]
+		if info.Mnemonic == "STOP" {
+			code[1] = 0x00
+		}
+		check(t, code)
+	}
+
+	for _, info := range cpu.CBOpcodeTable {
+		check(t, []byte{0xCB, info.Opcode})
+	}
+}
+
+func TestRun(t *testing.T) {
+	z := cpu.NewSM83()
+	z.LoadProgram(MustAssemble("ld a, $1F\nld b, $21\nadd a, b"), 0x0100)
+
+	for i := 0; i < 3; i++ {
+		if _, err := z.Step(); err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+	}
+
+	if z.A != 0x40 {
+		t.Errorf("expected A=%x, got %x", 0x40, z.A)
+	}
+}
@@ -0,0 +1,256 @@
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/danicat/gogoboy/cpu"
+)
+
+// operand kinds
+const (
+	register  = iota // registers and conditions: A, HL, NZ...
+	indirect         // memory addressed by a register: [HL], [C], [HL+]...
+	immediate        // an expression
+	direct           // memory addressed by an expression: [$C000]
+	spOffset         // SP plus an expression
+)
+
+type operand struct {
+	kind int
+	name string // for register and indirect, as in the opcode table: A, (HL)
+	expr string // for immediate, direct and spOffset
+}
+
+var registers = map[string]bool{
+	"A": true, "B": true, "C": true, "D": true, "E": true, "H": true, "L": true,
+	"AF": true, "BC": true, "DE": true, "HL": true, "SP": true,
+	"NZ": true, "Z": true, "NC": true,
+}
+
+var indirectRegisters = map[string]string{
+	"HL": "(HL)", "BC": "(BC)", "DE": "(DE)", "C": "(C)", "$FF00+C": "(C)",
+	"HL+": "(HL+)", "HLI": "(HL+)", "HL-": "(HL-)", "HLD": "(HL-)",
+}
+
+// instructions indexes the opcode tables by mnemonic
+var instructions = map[string][]cpu.OpcodeInfo{}
+
+func init() {
+	for _, info := range cpu.OpcodeTable {
+		if info.Illegal || info.Mnemonic == "PREFIX" {
+			continue
+		}
+		instructions[info.Mnemonic] = append(instructions[info.Mnemonic], info)
+	}
+	for _, info := range cpu.CBOpcodeTable {
+		instructions[info.Mnemonic] = append(instructions[info.Mnemonic], info)
+	}
+}
+
+func parseOperand(s string) operand {
+	compact := strings.ToUpper(strings.Replace(s, " ", "", -1))
+	if registers[compact] {
+		return operand{kind: register, name: compact}
+	}
+
+	if len(compact) >= 2 && (compact[0] == '[' && compact[len(compact)-1] == ']' || compact[0] == '(' && compact[len(compact)-1] == ')') {
+		inner := compact[1 : len(compact)-1]
+		if name, ok := indirectRegisters[inner]; ok {
+			return operand{kind: indirect, name: name}
+		}
+
+		// parentheses are only used for memory with registers, otherwise
+		// they group an expression
+		if compact[0] == '[' {
+			return operand{kind: direct, expr: strings.TrimSpace(s[1 : len(s)-1])}
+		}
+	}
+
+	if strings.HasPrefix(compact, "SP+") || strings.HasPrefix(compact, "SP-") {
+		return operand{kind: spOffset, expr: strings.TrimSpace(s)[2:]}
+	}
+
+	return operand{kind: immediate, expr: s}
+}
+
+// encode returns the bytes for one instruction
+func (a *assembler) encode(mnemonic string, args []string) ([]byte, error) {
+	mnemonic = strings.ToUpper(mnemonic)
+	ops := make([]operand, len(args))
+	for i, s := range args {
+		ops[i] = parseOperand(s)
+	}
+
+	mnemonic, ops = normalize(mnemonic, ops)
+
+	candidates, ok := instructions[mnemonic]
+	if !ok {
+		return nil, fmt.Errorf("unknown instruction %q", strings.ToLower(mnemonic))
+	}
+
+	for _, info := range candidates {
+		b, ok, err := a.match(info, ops)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid operands for %s: %s", strings.ToLower(mnemonic), strings.Join(args, ", "))
+}
+
+// normalize rewrites the RGBDS forms of an instruction into the form used by
+// the opcode table
+func normalize(mnemonic string, ops []operand) (string, []operand) {
+	switch mnemonic {
+	case "LD", "LDH":
+		for i, o := range ops {
+			switch o.name {
+			case "(HL+)":
+				mnemonic, ops[i].name = "LDI", "(HL)"
+			case "(HL-)":
+				mnemonic, ops[i].name = "LDD", "(HL)"
+			case "(C)":
+				mnemonic = "LD"
+			}
+		}
+	case "LDI", "LDD":
+		for i, o := range ops {
+			if o.name == "(HL+)" || o.name == "(HL-)" {
+				ops[i].name = "(HL)"
+			}
+		}
+	case "JP":
+		if len(ops) == 1 && ops[0].kind == register && ops[0].name == "HL" {
+			ops[0] = operand{kind: indirect, name: "(HL)"}
+		}
+	case "ADD", "ADC", "SUB", "SBC", "AND", "OR", "XOR", "CP":
+		if len(ops) == 1 {
+			ops = append([]operand{{kind: register, name: "A"}}, ops...)
+		}
+	}
+	return mnemonic, ops
+}
+
+// match tries to encode ops with the instruction described by info. ok is
+// false when the operands don't fit this instruction.
+func (a *assembler) match(info cpu.OpcodeInfo, ops []operand) (b []byte, ok bool, err error) {
+	if len(ops) != len(info.Operands) {
+		return nil, false, nil
+	}
+
+	if info.Prefixed {
+		b = append(b, 0xCB)
+	}
+	b = append(b, info.Opcode)
+
+	for i, t := range info.Operands {
+		o := ops[i]
+
+		switch t {
+		case "n":
+			if o.kind != immediate {
+				return nil, false, nil
+			}
+			v, err := a.value(o.expr, -128, 255)
+			if err != nil {
+				return nil, false, err
+			}
+			b = append(b, byte(v))
+
+		case "nn":
+			if o.kind != immediate {
+				return nil, false, nil
+			}
+			v, err := a.value(o.expr, -32768, 65535)
+			if err != nil {
+				return nil, false, err
+			}
+			b = append(b, byte(v), byte(v>>8))
+
+		case "(n)":
+			if o.kind != direct {
+				return nil, false, nil
+			}
+			v, err := a.value(o.expr, 0, 65535)
+			if err != nil {
+				return nil, false, err
+			}
+			if a.final && v > 0xFF && v < 0xFF00 {
+				return nil, false, fmt.Errorf("address %q is not in the high page", o.expr)
+			}
+			b = append(b, byte(v))
+
+		case "(nn)":
+			if o.kind != direct {
+				return nil, false, nil
+			}
+			v, err := a.value(o.expr, 0, 65535)
+			if err != nil {
+				return nil, false, err
+			}
+			b = append(b, byte(v), byte(v>>8))
+
+		case "e", "SP+e":
+			if t == "e" && o.kind != immediate || t == "SP+e" && o.kind != spOffset {
+				return nil, false, nil
+			}
+
+			v, _, err := a.eval(o.expr)
+			if err != nil {
+				return nil, false, err
+			}
+			if info.Mnemonic == "JR" {
+				v -= int(a.pc) + info.Length
+			}
+			if a.final && (v < -128 || v > 127) {
+				return nil, false, fmt.Errorf("offset %d of %q out of range", v, o.expr)
+			}
+			b = append(b, byte(v))
+
+		default:
+			if n, numeric := literal(info, t); numeric {
+				// RST vectors and bit numbers are part of the opcode
+				if o.kind != immediate {
+					return nil, false, nil
+				}
+				v, known, err := a.eval(o.expr)
+				if err != nil {
+					return nil, false, err
+				}
+				if known && v != n {
+					return nil, false, nil
+				}
+				continue
+			}
+
+			if o.kind != register && o.kind != indirect || o.name != t {
+				return nil, false, nil
+			}
+		}
+	}
+
+	// STOP is followed by a padding byte
+	for len(b) < info.Length {
+		b = append(b, 0x00)
+	}
+	return b, true, nil
+}
+
+// literal returns the value of operands that are numbers in the opcode
+// table, like the vector of RST 38H or the bit of BIT 7, B
+func literal(info cpu.OpcodeInfo, t string) (int, bool) {
+	switch info.Mnemonic {
+	case "RST":
+		v, err := strconv.ParseInt(strings.TrimSuffix(t, "H"), 16, 64)
+		return int(v), err == nil
+	case "BIT", "RES", "SET":
+		v, err := strconv.Atoi(t)
+		return v, err == nil
+	}
+	return 0, false
+}
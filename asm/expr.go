@@ -0,0 +1,223 @@
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// parser evaluates an expression such as "(label + 2) & $FF". Symbols that
+// are not defined yet make the result unknown, which is fine on the first
+// pass where only the size of each instruction matters.
+type parser struct {
+	s       string
+	pos     int
+	a       *assembler
+	unknown bool
+}
+
+// eval returns the value of expr. known is false when expr refers to symbols
+// that are not defined yet.
+func (a *assembler) eval(expr string) (value int, known bool, err error) {
+	p := &parser{s: expr, a: a}
+	v, err := p.binary(0)
+	if err != nil {
+		return 0, false, err
+	}
+	p.space()
+	if p.pos < len(p.s) {
+		return 0, false, fmt.Errorf("unexpected %q in expression %q", p.s[p.pos:], expr)
+	}
+	return v, !p.unknown, nil
+}
+
+// binary operators by precedence, lowest first
+var precedence = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) binary(level int) (int, error) {
+	if level == len(precedence) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := p.operator(precedence[level])
+		if op == "" {
+			return left, nil
+		}
+
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return 0, err
+		}
+
+		switch op {
+		case "|":
+			left |= right
+		case "^":
+			left ^= right
+		case "&":
+			left &= right
+		case "<<":
+			left <<= uint(right)
+		case ">>":
+			left >>= uint(right)
+		case "+":
+			left += right
+		case "-":
+			left -= right
+		case "*":
+			left *= right
+		case "/", "%":
+			if right == 0 {
+				if p.unknown {
+					continue
+				}
+				return 0, fmt.Errorf("division by zero in %q", p.s)
+			}
+			if op == "/" {
+				left /= right
+			} else {
+				left %= right
+			}
+		}
+	}
+}
+
+func (p *parser) operator(ops []string) string {
+	p.space()
+	for _, op := range ops {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *parser) unary() (int, error) {
+	p.space()
+	if p.pos >= len(p.s) {
+		return 0, fmt.Errorf("incomplete expression %q", p.s)
+	}
+
+	switch p.s[p.pos] {
+	case '-':
+		p.pos++
+		v, err := p.unary()
+		return -v, err
+	case '+':
+		p.pos++
+		return p.unary()
+	case '~':
+		p.pos++
+		v, err := p.unary()
+		return ^v, err
+	case '(':
+		p.pos++
+		v, err := p.binary(0)
+		if err != nil {
+			return 0, err
+		}
+		if p.operator([]string{")"}) == "" {
+			return 0, fmt.Errorf("missing ) in %q", p.s)
+		}
+		return v, nil
+	}
+
+	return p.primary()
+}
+
+func (p *parser) primary() (int, error) {
+	s := p.s[p.pos:]
+
+	switch {
+	case s[0] == '@':
+		p.pos++
+		return int(p.a.pc), nil
+	case s[0] == '\'' && len(s) >= 3 && s[2] == '\'':
+		p.pos += 3
+		return int(s[1]), nil
+	case s[0] == '$':
+		return p.number(1, 16)
+	case s[0] == '%':
+		return p.number(1, 2)
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		return p.number(2, 16)
+	case strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0B"):
+		return p.number(2, 2)
+	case s[0] >= '0' && s[0] <= '9':
+		return p.number(0, 10)
+	}
+
+	name := p.identifier()
+	if name == "" {
+		return 0, fmt.Errorf("unexpected %q in expression %q", s, p.s)
+	}
+
+	v, ok := p.a.lookup(name)
+	if !ok {
+		if p.a.final {
+			return 0, fmt.Errorf("undefined symbol %q", name)
+		}
+		p.unknown = true
+	}
+	return int(v), nil
+}
+
+func (p *parser) number(prefix, base int) (int, error) {
+	p.pos += prefix
+	start := p.pos
+	for p.pos < len(p.s) && isDigit(rune(p.s[p.pos]), base) {
+		p.pos++
+	}
+
+	v, err := strconv.ParseInt(strings.Replace(p.s[start:p.pos], "_", "", -1), base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number in %q", p.s)
+	}
+	return int(v), nil
+}
+
+func (p *parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.s) && isIdent(rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *parser) space() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' || p.pos < len(p.s) && p.s[p.pos] == '\t' {
+		p.pos++
+	}
+}
+
+func isDigit(r rune, base int) bool {
+	if r == '_' {
+		return true
+	}
+	switch base {
+	case 2:
+		return r == '0' || r == '1'
+	case 10:
+		return r >= '0' && r <= '9'
+	}
+	return unicode.Is(unicode.ASCII_Hex_Digit, r)
+}
+
+func isIdent(r rune) bool {
+	return r == '_' || r == '.' || r == '#' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
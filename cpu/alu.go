@@ -1,6 +1,6 @@
 package cpu
 
-func (z *Z80) add8(l, r byte, carry bool) byte {
+func (z *SM83) add8(l, r byte, carry bool) byte {
 	var res int16 = int16(l) + int16(r)
 	var halfCarry = l&0x0F + r&0x0F
 
@@ -9,7 +9,7 @@ func (z *Z80) add8(l, r byte, carry bool) byte {
 		halfCarry++
 	}
 
-	if res == 0 {
+	if byte(res) == 0 {
 		z.SetZFlag()
 	} else {
 		z.ResetZFlag()
@@ -32,13 +32,233 @@ func (z *Z80) add8(l, r byte, carry bool) byte {
 	return byte(res)
 }
 
-func (z *Z80) dec(hi, lo *byte) {
+func (z *SM83) sub8(l, r byte, carry bool) byte {
+	var res int16 = int16(l) - int16(r)
+	var halfCarry int16 = int16(l&0x0F) - int16(r&0x0F)
+
+	if carry {
+		res--
+		halfCarry--
+	}
+
+	if byte(res) == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+
+	z.SetNFlag()
+
+	if halfCarry < 0 {
+		z.SetHFlag()
+	} else {
+		z.ResetHFlag()
+	}
+
+	if res < 0 {
+		z.SetCFlag()
+	} else {
+		z.ResetCFlag()
+	}
+
+	return byte(res)
+}
+
+// cp compares A with r by subtracting without storing the result
+func (z *SM83) cp(r byte) {
+	z.sub8(z.A, r, false)
+}
+
+func (z *SM83) and8(l, r byte) byte {
+	res := l & r
+
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+	z.ResetNFlag()
+	z.SetHFlag()
+	z.ResetCFlag()
+
+	return res
+}
+
+func (z *SM83) or8(l, r byte) byte {
+	res := l | r
+
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+	z.ResetNFlag()
+	z.ResetHFlag()
+	z.ResetCFlag()
+
+	return res
+}
+
+func (z *SM83) xor8(l, r byte) byte {
+	res := l ^ r
+
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+	z.ResetNFlag()
+	z.ResetHFlag()
+	z.ResetCFlag()
+
+	return res
+}
+
+// inc8 increments an 8-bit value. The carry flag is not affected.
+func (z *SM83) inc8(v byte) byte {
+	res := v + 1
+
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+
+	z.ResetNFlag()
+
+	if v&0x0F == 0x0F {
+		z.SetHFlag()
+	} else {
+		z.ResetHFlag()
+	}
+
+	return res
+}
+
+// dec8 decrements an 8-bit value. The carry flag is not affected.
+func (z *SM83) dec8(v byte) byte {
+	res := v - 1
+
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+
+	z.SetNFlag()
+
+	if v&0x0F == 0x00 {
+		z.SetHFlag()
+	} else {
+		z.ResetHFlag()
+	}
+
+	return res
+}
+
+// daa adjusts A to a valid BCD number after an addition or subtraction
+func (z *SM83) daa() {
+	if !z.NFlag() {
+		if z.CFlag() || z.A > 0x99 {
+			z.A += 0x60
+			z.SetCFlag()
+		}
+		if z.HFlag() || z.A&0x0F > 0x09 {
+			z.A += 0x06
+		}
+	} else {
+		if z.CFlag() {
+			z.A -= 0x60
+		}
+		if z.HFlag() {
+			z.A -= 0x06
+		}
+	}
+
+	if z.A == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+
+	z.ResetHFlag()
+}
+
+func (z *SM83) cpl() {
+	z.A = ^z.A
+	z.SetNFlag()
+	z.SetHFlag()
+}
+
+func (z *SM83) scf() {
+	z.ResetNFlag()
+	z.ResetHFlag()
+	z.SetCFlag()
+}
+
+func (z *SM83) ccf() {
+	z.ResetNFlag()
+	z.ResetHFlag()
+	if z.CFlag() {
+		z.ResetCFlag()
+	} else {
+		z.SetCFlag()
+	}
+}
+
+// add16 adds two 16-bit values. The zero flag is not affected and the
+// half carry is taken from bit 11. The 16-bit adder takes an extra M-cycle.
+func (z *SM83) add16(l, r uint16) uint16 {
+	res := uint32(l) + uint32(r)
+	z.internal()
+
+	z.ResetNFlag()
+
+	if l&0x0FFF+r&0x0FFF > 0x0FFF {
+		z.SetHFlag()
+	} else {
+		z.ResetHFlag()
+	}
+
+	if res > 0xFFFF {
+		z.SetCFlag()
+	} else {
+		z.ResetCFlag()
+	}
+
+	return uint16(res)
+}
+
+// addSP returns SP plus the signed offset e. The half carry and carry flags
+// are computed from an unsigned addition of e to the low byte of SP.
+func (z *SM83) addSP(e byte) uint16 {
+	res := z.SP + uint16(int8(e))
+
+	z.ResetZFlag()
+	z.ResetNFlag()
+
+	if z.SP&0x000F+uint16(e&0x0F) > 0x0F {
+		z.SetHFlag()
+	} else {
+		z.ResetHFlag()
+	}
+
+	if z.SP&0x00FF+uint16(e) > 0xFF {
+		z.SetCFlag()
+	} else {
+		z.ResetCFlag()
+	}
+
+	return res
+}
+
+func (z *SM83) dec(hi, lo *byte) {
 	val := pair(*hi, *lo)
 	val--
 	*hi, *lo = split(val)
 }
 
-func (z *Z80) inc(hi, lo *byte) {
+func (z *SM83) inc(hi, lo *byte) {
 	val := pair(*hi, *lo)
 	val++
 	*hi, *lo = split(val)
@@ -12,68 +12,74 @@ func TestADD(t *testing.T) {
 		{
 			name:     "ADD A,A",
 			program:  []byte{0x87},
-			input:    Z80{A: 0x01},
-			expected: Z80{A: 0x02, F: 0b00000000},
+			input:    SM83{A: 0x01},
+			expected: SM83{A: 0x02, F: 0b00000000},
 		},
 		{
 			name:     "ADD A,A ZFlag Set",
 			program:  []byte{0x87},
-			input:    Z80{A: 0x00},
-			expected: Z80{A: 0x00, F: 0b10000000},
+			input:    SM83{A: 0x00},
+			expected: SM83{A: 0x00, F: 0b10000000},
 		},
 		{
 			name:     "ADD A,A HFlag Set",
 			program:  []byte{0x87},
-			input:    Z80{A: 0b00001000},
-			expected: Z80{A: 0b00010000, F: 0b00100000},
+			input:    SM83{A: 0b00001000},
+			expected: SM83{A: 0b00010000, F: 0b00100000},
 		},
 		{
 			name:     "ADD A,A CFlag Set",
 			program:  []byte{0x87},
-			input:    Z80{A: 0b10000000},
-			expected: Z80{A: 0b00000000, F: 0b00010000},
+			input:    SM83{A: 0b10000000},
+			expected: SM83{A: 0b00000000, F: 0b10010000},
 		},
 		{
 			name:     "ADD A,B",
 			program:  []byte{0x80},
-			input:    Z80{A: 0b00001100, B: 0b00001000},
-			expected: Z80{A: 0b00010100, F: 0b00100000},
+			input:    SM83{A: 0b00001100, B: 0b00001000},
+			expected: SM83{A: 0b00010100, F: 0b00100000},
 		},
 		{
 			name:     "ADD A,C",
 			program:  []byte{0x81},
-			input:    Z80{A: 0b00001100, C: 0b00010000},
-			expected: Z80{A: 0b00011100, F: 0b00000000},
+			input:    SM83{A: 0b00001100, C: 0b00010000},
+			expected: SM83{A: 0b00011100, F: 0b00000000},
 		},
 		{
 			name:     "ADD A,D",
 			program:  []byte{0x82},
-			input:    Z80{A: 0b10001100, D: 0b10001000},
-			expected: Z80{A: 0b00010100, F: 0b00110000},
+			input:    SM83{A: 0b10001100, D: 0b10001000},
+			expected: SM83{A: 0b00010100, F: 0b00110000},
 		},
 		{
 			name:     "ADD A,E",
 			program:  []byte{0x83},
-			input:    Z80{A: 0b00001100, E: 0b00001100},
-			expected: Z80{A: 0b00011000, F: 0b00100000},
+			input:    SM83{A: 0b00001100, E: 0b00001100},
+			expected: SM83{A: 0b00011000, F: 0b00100000},
 		},
 		{
 			name:     "ADD A,H",
 			program:  []byte{0x84},
-			input:    Z80{A: 0b00001100, H: 0b00000011},
-			expected: Z80{A: 0b00001111, F: 0b00000000},
+			input:    SM83{A: 0b00001100, H: 0b00000011},
+			expected: SM83{A: 0b00001111, F: 0b00000000},
 		},
 		{
 			name:     "ADD A,L",
 			program:  []byte{0x85},
-			input:    Z80{A: 0b00001100, L: 0b00001000},
-			expected: Z80{A: 0b00010100, F: 0b00100000},
+			input:    SM83{A: 0b00001100, L: 0b00001000},
+			expected: SM83{A: 0b00010100, F: 0b00100000},
+		},
+		{
+			name:     "ADD A,(HL)",
+			program:  []byte{0x86, 0x0F},
+			input:    SM83{A: 0x01, L: 0x01},
+			expected: SM83{A: 0x10, F: 0b00100000},
 		},
 		{
 			name:     "ADD A,#",
 			program:  []byte{0xC6, 0x10},
-			input:    Z80{A: 0x20, F: 0b00010000},
-			expected: Z80{A: 0x30, F: 0b00000000},
+			input:    SM83{A: 0x20, F: 0b00010000},
+			expected: SM83{A: 0x30, F: 0b00000000},
 		},
 	}
 
@@ -104,56 +110,616 @@ func TestADCA(t *testing.T) {
 		{
 			name:     "ADC A,A",
 			program:  []byte{0x8F},
-			input:    Z80{A: 0x01},
-			expected: Z80{A: 0x02, F: 0b00000000},
+			input:    SM83{A: 0x01},
+			expected: SM83{A: 0x02, F: 0b00000000},
 		},
 		{
 			name:     "ADC A,A CFlag Set",
 			program:  []byte{0x8F},
-			input:    Z80{A: 0x00, F: 0b00010000},
-			expected: Z80{A: 0x01, F: 0b00000000},
+			input:    SM83{A: 0x00, F: 0b00010000},
+			expected: SM83{A: 0x01, F: 0b00000000},
 		},
 		{
 			name:     "ADC A,B",
 			program:  []byte{0x88},
-			input:    Z80{A: 0b00001100, B: 0b00001000},
-			expected: Z80{A: 0b00010100, F: 0b00100000},
+			input:    SM83{A: 0b00001100, B: 0b00001000},
+			expected: SM83{A: 0b00010100, F: 0b00100000},
 		},
 		{
 			name:     "ADC A,C",
 			program:  []byte{0x89},
-			input:    Z80{A: 0b00001100, C: 0b00010000},
-			expected: Z80{A: 0b00011100, F: 0b00000000},
+			input:    SM83{A: 0b00001100, C: 0b00010000},
+			expected: SM83{A: 0b00011100, F: 0b00000000},
 		},
 		{
 			name:     "ADC A,D",
 			program:  []byte{0x8A},
-			input:    Z80{A: 0b10001100, D: 0b10001000},
-			expected: Z80{A: 0b00010100, F: 0b00110000},
+			input:    SM83{A: 0b10001100, D: 0b10001000},
+			expected: SM83{A: 0b00010100, F: 0b00110000},
 		},
 		{
 			name:     "ADC A,E",
 			program:  []byte{0x8B},
-			input:    Z80{A: 0b00001100, E: 0b00001100},
-			expected: Z80{A: 0b00011000, F: 0b00100000},
+			input:    SM83{A: 0b00001100, E: 0b00001100},
+			expected: SM83{A: 0b00011000, F: 0b00100000},
 		},
 		{
 			name:     "ADC A,H",
 			program:  []byte{0x8C},
-			input:    Z80{A: 0b00001100, H: 0b00000011},
-			expected: Z80{A: 0b00001111, F: 0b00000000},
+			input:    SM83{A: 0b00001100, H: 0b00000011},
+			expected: SM83{A: 0b00001111, F: 0b00000000},
 		},
 		{
 			name:     "ADC A,L",
 			program:  []byte{0x8D},
-			input:    Z80{A: 0b00001100, L: 0b00001000},
-			expected: Z80{A: 0b00010100, F: 0b00100000},
+			input:    SM83{A: 0b00001100, L: 0b00001000},
+			expected: SM83{A: 0b00010100, F: 0b00100000},
+		},
+		{
+			name:     "ADC A,(HL)",
+			program:  []byte{0x8E, 0xFF},
+			input:    SM83{A: 0x00, F: 0b00010000, L: 0x01},
+			expected: SM83{A: 0x00, F: 0b10110000},
 		},
 		{
 			name:     "ADC A,#",
 			program:  []byte{0xCE, 0x10},
-			input:    Z80{A: 0x20, F: 0b00010000},
-			expected: Z80{A: 0x31, F: 0b00000000},
+			input:    SM83{A: 0x20, F: 0b00010000},
+			expected: SM83{A: 0x31, F: 0b00000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestSUB(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "SUB A,A",
+			program:  []byte{0x97},
+			input:    SM83{A: 0x3E},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+		{
+			name:     "SUB A,B",
+			program:  []byte{0x90},
+			input:    SM83{A: 0x3E, B: 0x3E},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+		{
+			name:     "SUB A,C HFlag Set",
+			program:  []byte{0x91},
+			input:    SM83{A: 0x3E, C: 0x0F},
+			expected: SM83{A: 0x2F, F: 0b01100000},
+		},
+		{
+			name:     "SUB A,D CFlag Set",
+			program:  []byte{0x92},
+			input:    SM83{A: 0x3E, D: 0x40},
+			expected: SM83{A: 0xFE, F: 0b01010000},
+		},
+		{
+			name:     "SUB A,E",
+			program:  []byte{0x93},
+			input:    SM83{A: 0x3E, E: 0x01},
+			expected: SM83{A: 0x3D, F: 0b01000000},
+		},
+		{
+			name:     "SUB A,H",
+			program:  []byte{0x94},
+			input:    SM83{A: 0x10, H: 0x01},
+			expected: SM83{A: 0x0F, F: 0b01100000},
+		},
+		{
+			name:     "SUB A,L",
+			program:  []byte{0x95},
+			input:    SM83{A: 0x10, L: 0x10},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+		{
+			name:     "SUB A,(HL)",
+			program:  []byte{0x96, 0x01},
+			input:    SM83{A: 0x00, L: 0x01},
+			expected: SM83{A: 0xFF, F: 0b01110000},
+		},
+		{
+			name:     "SUB A,#",
+			program:  []byte{0xD6, 0x0F},
+			input:    SM83{A: 0x3E},
+			expected: SM83{A: 0x2F, F: 0b01100000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestSBC(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "SBC A,A",
+			program:  []byte{0x9F},
+			input:    SM83{A: 0x3E},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+		{
+			name:     "SBC A,A CFlag Set",
+			program:  []byte{0x9F},
+			input:    SM83{A: 0x3E, F: 0b00010000},
+			expected: SM83{A: 0xFF, F: 0b01110000},
+		},
+		{
+			name:     "SBC A,B",
+			program:  []byte{0x98},
+			input:    SM83{A: 0x3B, B: 0x2A, F: 0b00010000},
+			expected: SM83{A: 0x10, F: 0b01000000},
+		},
+		{
+			name:     "SBC A,C",
+			program:  []byte{0x99},
+			input:    SM83{A: 0x3B, C: 0x3A, F: 0b00010000},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+		{
+			name:     "SBC A,D",
+			program:  []byte{0x9A},
+			input:    SM83{A: 0x3B, D: 0x4F, F: 0b00010000},
+			expected: SM83{A: 0xEB, F: 0b01110000},
+		},
+		{
+			name:     "SBC A,E",
+			program:  []byte{0x9B},
+			input:    SM83{A: 0x10, E: 0x0F},
+			expected: SM83{A: 0x01, F: 0b01100000},
+		},
+		{
+			name:     "SBC A,H",
+			program:  []byte{0x9C},
+			input:    SM83{A: 0x10, H: 0x00, F: 0b00010000},
+			expected: SM83{A: 0x0F, F: 0b01100000},
+		},
+		{
+			name:     "SBC A,L",
+			program:  []byte{0x9D},
+			input:    SM83{A: 0x00, L: 0x00, F: 0b00010000},
+			expected: SM83{A: 0xFF, F: 0b01110000},
+		},
+		{
+			name:     "SBC A,(HL)",
+			program:  []byte{0x9E, 0x4F},
+			input:    SM83{A: 0x3B, L: 0x01, F: 0b00010000},
+			expected: SM83{A: 0xEB, F: 0b01110000},
+		},
+		{
+			name:     "SBC A,#",
+			program:  []byte{0xDE, 0x3A},
+			input:    SM83{A: 0x3B, F: 0b00010000},
+			expected: SM83{A: 0x00, F: 0b11000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestAND(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "AND A,A",
+			program:  []byte{0xA7},
+			input:    SM83{A: 0x5A},
+			expected: SM83{A: 0x5A, F: 0b00100000},
+		},
+		{
+			name:     "AND A,B",
+			program:  []byte{0xA0},
+			input:    SM83{A: 0x5A, B: 0x3F},
+			expected: SM83{A: 0x1A, F: 0b00100000},
+		},
+		{
+			name:     "AND A,C ZFlag Set",
+			program:  []byte{0xA1},
+			input:    SM83{A: 0x5A, C: 0x00},
+			expected: SM83{A: 0x00, F: 0b10100000},
+		},
+		{
+			name:     "AND A,D",
+			program:  []byte{0xA2},
+			input:    SM83{A: 0x5A, D: 0x0F, F: 0b01010000},
+			expected: SM83{A: 0x0A, F: 0b00100000},
+		},
+		{
+			name:     "AND A,E",
+			program:  []byte{0xA3},
+			input:    SM83{A: 0xFF, E: 0xF0},
+			expected: SM83{A: 0xF0, F: 0b00100000},
+		},
+		{
+			name:     "AND A,H",
+			program:  []byte{0xA4},
+			input:    SM83{A: 0xFF, H: 0x01},
+			expected: SM83{A: 0x01, F: 0b00100000},
+		},
+		{
+			name:     "AND A,L",
+			program:  []byte{0xA5},
+			input:    SM83{A: 0xFF, L: 0x80},
+			expected: SM83{A: 0x80, F: 0b00100000},
+		},
+		{
+			name:     "AND A,(HL)",
+			program:  []byte{0xA6, 0x38},
+			input:    SM83{A: 0x5A, L: 0x01},
+			expected: SM83{A: 0x18, F: 0b00100000},
+		},
+		{
+			name:     "AND A,#",
+			program:  []byte{0xE6, 0x38},
+			input:    SM83{A: 0x5A},
+			expected: SM83{A: 0x18, F: 0b00100000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestOR(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "OR A,A",
+			program:  []byte{0xB7},
+			input:    SM83{A: 0x5A},
+			expected: SM83{A: 0x5A, F: 0b00000000},
+		},
+		{
+			name:     "OR A,A ZFlag Set",
+			program:  []byte{0xB7},
+			input:    SM83{A: 0x00, F: 0b01110000},
+			expected: SM83{A: 0x00, F: 0b10000000},
+		},
+		{
+			name:     "OR A,B",
+			program:  []byte{0xB0},
+			input:    SM83{A: 0x5A, B: 0x03},
+			expected: SM83{A: 0x5B, F: 0b00000000},
+		},
+		{
+			name:     "OR A,C",
+			program:  []byte{0xB1},
+			input:    SM83{A: 0x5A, C: 0x0F},
+			expected: SM83{A: 0x5F, F: 0b00000000},
+		},
+		{
+			name:     "OR A,D",
+			program:  []byte{0xB2},
+			input:    SM83{A: 0x00, D: 0x80},
+			expected: SM83{A: 0x80, F: 0b00000000},
+		},
+		{
+			name:     "OR A,E",
+			program:  []byte{0xB3},
+			input:    SM83{A: 0x01, E: 0x02},
+			expected: SM83{A: 0x03, F: 0b00000000},
+		},
+		{
+			name:     "OR A,H",
+			program:  []byte{0xB4},
+			input:    SM83{A: 0x10, H: 0x01},
+			expected: SM83{A: 0x11, F: 0b00000000},
+		},
+		{
+			name:     "OR A,L",
+			program:  []byte{0xB5},
+			input:    SM83{A: 0xF0, L: 0x0F},
+			expected: SM83{A: 0xFF, F: 0b00000000},
+		},
+		{
+			name:     "OR A,(HL)",
+			program:  []byte{0xB6, 0x0F},
+			input:    SM83{A: 0x5A, L: 0x01},
+			expected: SM83{A: 0x5F, F: 0b00000000},
+		},
+		{
+			name:     "OR A,#",
+			program:  []byte{0xF6, 0x03},
+			input:    SM83{A: 0x5A},
+			expected: SM83{A: 0x5B, F: 0b00000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestXOR(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "XOR A,A",
+			program:  []byte{0xAF},
+			input:    SM83{A: 0xFF, F: 0b01110000},
+			expected: SM83{A: 0x00, F: 0b10000000},
+		},
+		{
+			name:     "XOR A,B",
+			program:  []byte{0xA8},
+			input:    SM83{A: 0xFF, B: 0x0F},
+			expected: SM83{A: 0xF0, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,C",
+			program:  []byte{0xA9},
+			input:    SM83{A: 0xFF, C: 0xFF},
+			expected: SM83{A: 0x00, F: 0b10000000},
+		},
+		{
+			name:     "XOR A,D",
+			program:  []byte{0xAA},
+			input:    SM83{A: 0xFF, D: 0x8A},
+			expected: SM83{A: 0x75, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,E",
+			program:  []byte{0xAB},
+			input:    SM83{A: 0x0F, E: 0xF0},
+			expected: SM83{A: 0xFF, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,H",
+			program:  []byte{0xAC},
+			input:    SM83{A: 0x01, H: 0x03},
+			expected: SM83{A: 0x02, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,L",
+			program:  []byte{0xAD},
+			input:    SM83{A: 0xAA, L: 0x55},
+			expected: SM83{A: 0xFF, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,(HL)",
+			program:  []byte{0xAE, 0x8A},
+			input:    SM83{A: 0xFF, L: 0x01},
+			expected: SM83{A: 0x75, F: 0b00000000},
+		},
+		{
+			name:     "XOR A,#",
+			program:  []byte{0xEE, 0x0F},
+			input:    SM83{A: 0xFF},
+			expected: SM83{A: 0xF0, F: 0b00000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestCP(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "CP A,A",
+			program:  []byte{0xBF},
+			input:    SM83{A: 0x3C},
+			expected: SM83{A: 0x3C, F: 0b11000000},
+		},
+		{
+			name:     "CP A,B",
+			program:  []byte{0xB8},
+			input:    SM83{A: 0x3C, B: 0x2F},
+			expected: SM83{A: 0x3C, F: 0b01100000},
+		},
+		{
+			name:     "CP A,C",
+			program:  []byte{0xB9},
+			input:    SM83{A: 0x3C, C: 0x3C},
+			expected: SM83{A: 0x3C, F: 0b11000000},
+		},
+		{
+			name:     "CP A,D",
+			program:  []byte{0xBA},
+			input:    SM83{A: 0x3C, D: 0x40},
+			expected: SM83{A: 0x3C, F: 0b01010000},
+		},
+		{
+			name:     "CP A,E",
+			program:  []byte{0xBB},
+			input:    SM83{A: 0x3C, E: 0x01},
+			expected: SM83{A: 0x3C, F: 0b01000000},
+		},
+		{
+			name:     "CP A,H",
+			program:  []byte{0xBC},
+			input:    SM83{A: 0x3C, H: 0x3D},
+			expected: SM83{A: 0x3C, F: 0b01110000},
+		},
+		{
+			name:     "CP A,L",
+			program:  []byte{0xBD},
+			input:    SM83{A: 0x3C, L: 0x0C},
+			expected: SM83{A: 0x3C, F: 0b01000000},
+		},
+		{
+			name:     "CP A,(HL)",
+			program:  []byte{0xBE, 0x40},
+			input:    SM83{A: 0x3C, L: 0x01},
+			expected: SM83{A: 0x3C, F: 0b01010000},
+		},
+		{
+			name:     "CP A,#",
+			program:  []byte{0xFE, 0x3C},
+			input:    SM83{A: 0x3C},
+			expected: SM83{A: 0x3C, F: 0b11000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestDAA(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "DAA after ADD",
+			program:  []byte{0x27},
+			input:    SM83{A: 0x7D},
+			expected: SM83{A: 0x83, F: 0b00000000},
+		},
+		{
+			name:     "DAA after ADD HFlag Set",
+			program:  []byte{0x27},
+			input:    SM83{A: 0x80, F: 0b00100000},
+			expected: SM83{A: 0x86, F: 0b00000000},
+		},
+		{
+			name:     "DAA after ADD CFlag Set",
+			program:  []byte{0x27},
+			input:    SM83{A: 0x9A},
+			expected: SM83{A: 0x00, F: 0b10010000},
+		},
+		{
+			name:     "DAA after ADD keeps CFlag",
+			program:  []byte{0x27},
+			input:    SM83{A: 0x20, F: 0b00010000},
+			expected: SM83{A: 0x80, F: 0b00010000},
+		},
+		{
+			name:     "DAA after SUB",
+			program:  []byte{0x27},
+			input:    SM83{A: 0x0F, F: 0b01100000},
+			expected: SM83{A: 0x09, F: 0b01000000},
+		},
+		{
+			name:     "DAA after SUB CFlag Set",
+			program:  []byte{0x27},
+			input:    SM83{A: 0xF0, F: 0b01010000},
+			expected: SM83{A: 0x90, F: 0b01010000},
 		},
 	}
 
@@ -179,55 +745,303 @@ func TestADCA(t *testing.T) {
 	}
 }
 
+func TestCPL_SCF_CCF(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "CPL",
+			program:  []byte{0x2F},
+			input:    SM83{A: 0x35},
+			expected: SM83{A: 0xCA, F: 0b01100000},
+		},
+		{
+			name:     "CPL keeps Z and C",
+			program:  []byte{0x2F},
+			input:    SM83{A: 0xFF, F: 0b10010000},
+			expected: SM83{A: 0x00, F: 0b11110000},
+		},
+		{
+			name:     "SCF",
+			program:  []byte{0x37},
+			input:    SM83{F: 0b11100000},
+			expected: SM83{F: 0b10010000},
+		},
+		{
+			name:     "CCF CFlag Set",
+			program:  []byte{0x3F},
+			input:    SM83{F: 0b11110000},
+			expected: SM83{F: 0b10000000},
+		},
+		{
+			name:     "CCF CFlag Reset",
+			program:  []byte{0x3F},
+			input:    SM83{F: 0b01100000},
+			expected: SM83{F: 0b00010000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestINC8_DEC8(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "INC A",
+			program:  []byte{0x3C},
+			input:    SM83{A: 0x01},
+			expected: SM83{A: 0x02},
+		},
+		{
+			name:     "INC B HFlag Set",
+			program:  []byte{0x04},
+			input:    SM83{B: 0x0F},
+			expected: SM83{B: 0x10, F: 0b00100000},
+		},
+		{
+			name:     "INC C ZFlag Set",
+			program:  []byte{0x0C},
+			input:    SM83{C: 0xFF},
+			expected: SM83{C: 0x00, F: 0b10100000},
+		},
+		{
+			name:     "INC D keeps CFlag",
+			program:  []byte{0x14},
+			input:    SM83{D: 0x01, F: 0b01010000},
+			expected: SM83{D: 0x02, F: 0b00010000},
+		},
+		{
+			name:     "INC E",
+			program:  []byte{0x1C},
+			input:    SM83{E: 0x7F},
+			expected: SM83{E: 0x80, F: 0b00100000},
+		},
+		{
+			name:     "INC H",
+			program:  []byte{0x24},
+			input:    SM83{H: 0x01},
+			expected: SM83{H: 0x02},
+		},
+		{
+			name:     "INC L",
+			program:  []byte{0x2C},
+			input:    SM83{L: 0x01},
+			expected: SM83{L: 0x02},
+		},
+		{
+			name:     "DEC A",
+			program:  []byte{0x3D},
+			input:    SM83{A: 0x02},
+			expected: SM83{A: 0x01, F: 0b01000000},
+		},
+		{
+			name:     "DEC B ZFlag Set",
+			program:  []byte{0x05},
+			input:    SM83{B: 0x01},
+			expected: SM83{F: 0b11000000},
+		},
+		{
+			name:     "DEC C HFlag Set",
+			program:  []byte{0x0D},
+			input:    SM83{C: 0x10},
+			expected: SM83{C: 0x0F, F: 0b01100000},
+		},
+		{
+			name:     "DEC D keeps CFlag",
+			program:  []byte{0x15},
+			input:    SM83{D: 0x00, F: 0b00010000},
+			expected: SM83{D: 0xFF, F: 0b01110000},
+		},
+		{
+			name:     "DEC E",
+			program:  []byte{0x1D},
+			input:    SM83{E: 0x80},
+			expected: SM83{E: 0x7F, F: 0b01100000},
+		},
+		{
+			name:     "DEC H",
+			program:  []byte{0x25},
+			input:    SM83{H: 0x02},
+			expected: SM83{H: 0x01, F: 0b01000000},
+		},
+		{
+			name:     "DEC L",
+			program:  []byte{0x2D},
+			input:    SM83{L: 0x02},
+			expected: SM83{L: 0x01, F: 0b01000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestINC8_DEC8_HL(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		input    SM83
+		value    byte
+		expected byte
+		flags    byte
+	}{
+		{
+			name:     "INC (HL)",
+			program:  []byte{0x34},
+			input:    SM83{H: 0xC0, L: 0x00},
+			value:    0x0F,
+			expected: 0x10,
+			flags:    0b00100000,
+		},
+		{
+			name:     "INC (HL) ZFlag Set",
+			program:  []byte{0x34},
+			input:    SM83{H: 0xC0, L: 0x00, F: 0b00010000},
+			value:    0xFF,
+			expected: 0x00,
+			flags:    0b10110000,
+		},
+		{
+			name:     "DEC (HL)",
+			program:  []byte{0x35},
+			input:    SM83{H: 0xC0, L: 0x00},
+			value:    0x10,
+			expected: 0x0F,
+			flags:    0b01100000,
+		},
+		{
+			name:     "DEC (HL) ZFlag Set",
+			program:  []byte{0x35},
+			input:    SM83{H: 0xC0, L: 0x00},
+			value:    0x01,
+			expected: 0x00,
+			flags:    0b11000000,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+			m.Write(tc.input.HL(), tc.value)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if value := m.Read(tc.input.HL()); value != tc.expected {
+				t.Errorf("expected %x, got %x", tc.expected, value)
+			}
+
+			if tc.input.F != tc.flags {
+				t.Errorf("expected flags %b, got %b", tc.flags, tc.input.F)
+			}
+
+			if tc.input.cycles != 12 {
+				t.Errorf("expected cycles=%d, got %d", 12, tc.input.cycles)
+			}
+		})
+	}
+}
+
 func TestINC_DEC(t *testing.T) {
 	tbl := []testcase{
 		{
 			name:     "DEC BC",
 			program:  []byte{0x0B},
-			input:    Z80{B: 0x02, C: 0x00},
-			expected: Z80{B: 0x01, C: 0xFF},
+			input:    SM83{B: 0x02, C: 0x00},
+			expected: SM83{B: 0x01, C: 0xFF},
 		},
 		{
 			name:     "DEC DE",
 			program:  []byte{0x1B},
-			input:    Z80{D: 0x02, E: 0x00},
-			expected: Z80{D: 0x01, E: 0xFF},
+			input:    SM83{D: 0x02, E: 0x00},
+			expected: SM83{D: 0x01, E: 0xFF},
 		},
 		{
 			name:     "DEC HL",
 			program:  []byte{0x2B},
-			input:    Z80{H: 0x02, L: 0x00},
-			expected: Z80{H: 0x01, L: 0xFF},
+			input:    SM83{H: 0x02, L: 0x00},
+			expected: SM83{H: 0x01, L: 0xFF},
 		},
 		{
 			name:     "DEC SP",
 			program:  []byte{0x3B},
-			input:    Z80{SP: 0x0200},
-			expected: Z80{SP: 0x01FF},
+			input:    SM83{SP: 0x0200},
+			expected: SM83{SP: 0x01FF},
 		},
 		{
 			name:     "INC BC",
 			program:  []byte{0x03},
-			input:    Z80{B: 0x01, C: 0xFF},
-			expected: Z80{B: 0x02, C: 0x00},
+			input:    SM83{B: 0x01, C: 0xFF},
+			expected: SM83{B: 0x02, C: 0x00},
 		},
 		{
 			name:     "INC DE",
 			program:  []byte{0x13},
-			input:    Z80{D: 0x01, E: 0xFF},
-			expected: Z80{D: 0x02, E: 0x00},
+			input:    SM83{D: 0x01, E: 0xFF},
+			expected: SM83{D: 0x02, E: 0x00},
 		},
 		{
 			name:     "INC HL",
 			program:  []byte{0x23},
-			input:    Z80{H: 0x01, L: 0xFF},
-			expected: Z80{H: 0x02, L: 0x00},
+			input:    SM83{H: 0x01, L: 0xFF},
+			expected: SM83{H: 0x02, L: 0x00},
 		},
 		{
 			name:     "INC SP",
 			program:  []byte{0x33},
-			input:    Z80{SP: 0x01FF},
-			expected: Z80{SP: 0x0200},
+			input:    SM83{SP: 0x01FF},
+			expected: SM83{SP: 0x0200},
 		},
 	}
 
@@ -265,3 +1079,116 @@ func TestINC_DEC(t *testing.T) {
 		})
 	}
 }
+
+// 16-Bit ALU
+func TestADD16(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "ADD HL, BC",
+			program:  []byte{0x09},
+			input:    SM83{H: 0x12, L: 0x34, B: 0x01, C: 0x01},
+			expected: SM83{H: 0x13, L: 0x35, B: 0x01, C: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "ADD HL, DE HFlag Set",
+			program:  []byte{0x19},
+			input:    SM83{H: 0x0F, L: 0xFF, D: 0x00, E: 0x01},
+			expected: SM83{F: 0b00100000, H: 0x10, L: 0x00, D: 0x00, E: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "ADD HL, HL CFlag Set",
+			program:  []byte{0x29},
+			input:    SM83{H: 0x80, L: 0x00},
+			expected: SM83{F: 0b00010000, H: 0x00, L: 0x00},
+			cycles:   8,
+		},
+		{
+			name:     "ADD HL, SP keeps ZFlag",
+			program:  []byte{0x39},
+			input:    SM83{F: 0b11000000, H: 0x8A, L: 0x23, SP: 0x8A23},
+			expected: SM83{F: 0b10110000, H: 0x14, L: 0x46, SP: 0x8A23},
+			cycles:   8,
+		},
+		{
+			name:     "ADD HL, BC low byte carry only",
+			program:  []byte{0x09},
+			input:    SM83{H: 0x00, L: 0xFF, B: 0x00, C: 0x01},
+			expected: SM83{H: 0x01, L: 0x00, B: 0x00, C: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "ADD SP, e",
+			program:  []byte{0xE8, 0x02},
+			input:    SM83{SP: 0xFFF8},
+			expected: SM83{SP: 0xFFFA},
+			cycles:   16,
+		},
+		{
+			name:     "ADD SP, e negative offset",
+			program:  []byte{0xE8, 0xFE},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{SP: 0x00FE},
+			cycles:   16,
+		},
+		{
+			name:     "ADD SP, e negative offset low byte carry",
+			program:  []byte{0xE8, 0xFF},
+			input:    SM83{F: 0b11000000, SP: 0x0001},
+			expected: SM83{F: 0b00110000, SP: 0x0000},
+			cycles:   16,
+		},
+		{
+			name:     "ADD SP, e low byte carry ignores bit 15",
+			program:  []byte{0xE8, 0x01},
+			input:    SM83{SP: 0xFFFF},
+			expected: SM83{F: 0b00110000, SP: 0x0000},
+			cycles:   16,
+		},
+		{
+			name:     "ADD SP, e half carry",
+			program:  []byte{0xE8, 0x08},
+			input:    SM83{SP: 0x0008},
+			expected: SM83{F: 0b00100000, SP: 0x0010},
+			cycles:   16,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
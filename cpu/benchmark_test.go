@@ -0,0 +1,74 @@
+package cpu
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// benchmarkCycles is the number of cycles emulated per benchmark iteration,
+// roughly a quarter of a second of DMG time
+const benchmarkCycles = 1 << 20
+
+// synthetic is a loop mixing loads, ALU, CB, stack and branch instructions
+var synthetic = []byte{
+	0x21, 0x00, 0xC0, // LD HL, 0xC000
+	0x06, 0x00, // LD B, 0
+	0x3C,       // loop: INC A
+	0x80,       // ADD A, B
+	0x77,       // LD (HL), A
+	0x4E,       // LD C, (HL)
+	0xA9,       // XOR A, C
+	0xCB, 0x37, // SWAP A
+	0xC5,       // PUSH BC
+	0xC1,       // POP BC
+	0x05,       // DEC B
+	0x20, 0xF4, // JR NZ, loop
+	0xC3, 0x03, 0x01, // JP 0x0103
+}
+
+func benchmarkProgram(b *testing.B, program []byte, addr uint16, cached bool) {
+	z := NewSM83()
+	z.SetBlockCache(cached)
+	var cycles int
+
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		z.Reset()
+		z.LoadProgram(program, addr)
+		z.SetMaxCycles(benchmarkCycles)
+		err := z.Run()
+		if err != nil {
+			b.Fatal(err)
+		}
+		cycles += z.cycles
+	}
+	elapsed := time.Since(start)
+
+	b.ReportMetric(float64(cycles)/elapsed.Seconds()/1e6, "MHz")
+}
+
+func BenchmarkSynthetic(b *testing.B) {
+	benchmarkProgram(b, synthetic, 0x0100, false)
+}
+
+func BenchmarkSyntheticCached(b *testing.B) {
+	benchmarkProgram(b, synthetic, 0x0100, true)
+}
+
+func BenchmarkBootstrapROM(b *testing.B) {
+	data, err := ioutil.ReadFile("testdata/DMG_ROM.bin")
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkProgram(b, data, 0, false)
+}
+
+func BenchmarkBootstrapROMCached(b *testing.B) {
+	data, err := ioutil.ReadFile("testdata/DMG_ROM.bin")
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkProgram(b, data, 0, true)
+}
@@ -0,0 +1,129 @@
+package cpu
+
+// rlca rotates A left, copying bit 7 into both bit 0 and the carry flag.
+// Unlike RLC A, the zero flag is always reset.
+func (z *SM83) rlca() {
+	z.A = z.rlc(z.A)
+	z.ResetZFlag()
+}
+
+// rrca rotates A right, copying bit 0 into both bit 7 and the carry flag
+func (z *SM83) rrca() {
+	z.A = z.rrc(z.A)
+	z.ResetZFlag()
+}
+
+// rla rotates A left through the carry flag
+func (z *SM83) rla() {
+	z.A = z.rl(z.A)
+	z.ResetZFlag()
+}
+
+// rra rotates A right through the carry flag
+func (z *SM83) rra() {
+	z.A = z.rr(z.A)
+	z.ResetZFlag()
+}
+
+// shiftFlags sets the flags shared by every CB rotate and shift instruction
+func (z *SM83) shiftFlags(res byte, carry byte) {
+	if res == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+	z.ResetNFlag()
+	z.ResetHFlag()
+	if carry == 1 {
+		z.SetCFlag()
+	} else {
+		z.ResetCFlag()
+	}
+}
+
+func (z *SM83) rlc(v byte) byte {
+	carry := v >> 7
+	res := v<<1 | carry
+	z.shiftFlags(res, carry)
+	return res
+}
+
+func (z *SM83) rrc(v byte) byte {
+	carry := v & 1
+	res := v>>1 | carry<<7
+	z.shiftFlags(res, carry)
+	return res
+}
+
+func (z *SM83) rl(v byte) byte {
+	var old byte
+	if z.CFlag() {
+		old = 1
+	}
+	carry := v >> 7
+	res := v<<1 | old
+	z.shiftFlags(res, carry)
+	return res
+}
+
+func (z *SM83) rr(v byte) byte {
+	var old byte
+	if z.CFlag() {
+		old = 1
+	}
+	carry := v & 1
+	res := v>>1 | old<<7
+	z.shiftFlags(res, carry)
+	return res
+}
+
+// sla shifts left into the carry flag. Bit 0 is reset.
+func (z *SM83) sla(v byte) byte {
+	carry := v >> 7
+	res := v << 1
+	z.shiftFlags(res, carry)
+	return res
+}
+
+// sra shifts right into the carry flag. Bit 7 is unchanged.
+func (z *SM83) sra(v byte) byte {
+	carry := v & 1
+	res := v>>1 | v&0x80
+	z.shiftFlags(res, carry)
+	return res
+}
+
+// srl shifts right into the carry flag. Bit 7 is reset.
+func (z *SM83) srl(v byte) byte {
+	carry := v & 1
+	res := v >> 1
+	z.shiftFlags(res, carry)
+	return res
+}
+
+// swap exchanges the upper and lower nibbles
+func (z *SM83) swap(v byte) byte {
+	res := v<<4 | v>>4
+	z.shiftFlags(res, 0)
+	return res
+}
+
+// bit tests bit b of v. The carry flag is not affected.
+func (z *SM83) bit(b uint, v byte) {
+	if v&(1<<b) == 0 {
+		z.SetZFlag()
+	} else {
+		z.ResetZFlag()
+	}
+
+	z.ResetNFlag()
+	z.SetHFlag()
+}
+
+func (z *SM83) res(b uint, v byte) byte {
+	return v &^ (1 << b)
+}
+
+func (z *SM83) set(b uint, v byte) byte {
+	return v | 1<<b
+}
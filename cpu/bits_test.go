@@ -0,0 +1,438 @@
+package cpu
+
+import (
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// Rotates & Shifts
+func TestRotateA(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "RLCA",
+			program:  []byte{0x07},
+			input:    SM83{A: 0x85},
+			expected: SM83{A: 0x0B, F: 0b00010000},
+		},
+		{
+			name:     "RLCA ZFlag Reset",
+			program:  []byte{0x07},
+			input:    SM83{A: 0x00, F: 0b10000000},
+			expected: SM83{A: 0x00, F: 0b00000000},
+		},
+		{
+			name:     "RRCA",
+			program:  []byte{0x0F},
+			input:    SM83{A: 0x3B},
+			expected: SM83{A: 0x9D, F: 0b00010000},
+		},
+		{
+			name:     "RLA",
+			program:  []byte{0x17},
+			input:    SM83{A: 0x95, F: 0b00010000},
+			expected: SM83{A: 0x2B, F: 0b00010000},
+		},
+		{
+			name:     "RLA CFlag Reset",
+			program:  []byte{0x17},
+			input:    SM83{A: 0x15},
+			expected: SM83{A: 0x2A, F: 0b00000000},
+		},
+		{
+			name:     "RRA",
+			program:  []byte{0x1F},
+			input:    SM83{A: 0x81},
+			expected: SM83{A: 0x40, F: 0b00010000},
+		},
+		{
+			name:     "RRA CFlag Set",
+			program:  []byte{0x1F},
+			input:    SM83{A: 0x80, F: 0b00010000},
+			expected: SM83{A: 0xC0, F: 0b00000000},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.A != tc.expected.A {
+				t.Errorf("expected %x, got %x", tc.expected.A, tc.input.A)
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+		})
+	}
+}
+
+func TestCBRotateShift(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "RLC B",
+			program:  []byte{0xCB, 0x00},
+			input:    SM83{B: 0x85},
+			expected: SM83{B: 0x0B, F: 0b00010000},
+			cycles:   8,
+		},
+		{
+			name:     "RLC A ZFlag Set",
+			program:  []byte{0xCB, 0x07},
+			input:    SM83{A: 0x00},
+			expected: SM83{A: 0x00, F: 0b10000000},
+			cycles:   8,
+		},
+		{
+			name:     "RRC C",
+			program:  []byte{0xCB, 0x09},
+			input:    SM83{C: 0x01},
+			expected: SM83{C: 0x80, F: 0b00010000},
+			cycles:   8,
+		},
+		{
+			name:     "RRC D",
+			program:  []byte{0xCB, 0x0A},
+			input:    SM83{D: 0x02, F: 0b01110000},
+			expected: SM83{D: 0x01, F: 0b00000000},
+			cycles:   8,
+		},
+		{
+			name:     "RL E",
+			program:  []byte{0xCB, 0x13},
+			input:    SM83{E: 0x80},
+			expected: SM83{E: 0x00, F: 0b10010000},
+			cycles:   8,
+		},
+		{
+			name:     "RL H CFlag Set",
+			program:  []byte{0xCB, 0x14},
+			input:    SM83{H: 0x11, F: 0b00010000},
+			expected: SM83{H: 0x23, F: 0b00000000},
+			cycles:   8,
+		},
+		{
+			name:     "RR L",
+			program:  []byte{0xCB, 0x1D},
+			input:    SM83{L: 0x01},
+			expected: SM83{L: 0x00, F: 0b10010000},
+			cycles:   8,
+		},
+		{
+			name:     "RR A CFlag Set",
+			program:  []byte{0xCB, 0x1F},
+			input:    SM83{A: 0x8A, F: 0b00010000},
+			expected: SM83{A: 0xC5, F: 0b00000000},
+			cycles:   8,
+		},
+		{
+			name:     "SLA D",
+			program:  []byte{0xCB, 0x22},
+			input:    SM83{D: 0x80},
+			expected: SM83{D: 0x00, F: 0b10010000},
+			cycles:   8,
+		},
+		{
+			name:     "SLA E",
+			program:  []byte{0xCB, 0x23},
+			input:    SM83{E: 0xFF},
+			expected: SM83{E: 0xFE, F: 0b00010000},
+			cycles:   8,
+		},
+		{
+			name:     "SRA A",
+			program:  []byte{0xCB, 0x2F},
+			input:    SM83{A: 0x8A},
+			expected: SM83{A: 0xC5, F: 0b00000000},
+			cycles:   8,
+		},
+		{
+			name:     "SRA B",
+			program:  []byte{0xCB, 0x28},
+			input:    SM83{B: 0x01},
+			expected: SM83{B: 0x00, F: 0b10010000},
+			cycles:   8,
+		},
+		{
+			name:     "SWAP A",
+			program:  []byte{0xCB, 0x37},
+			input:    SM83{A: 0xF0, F: 0b01110000},
+			expected: SM83{A: 0x0F, F: 0b00000000},
+			cycles:   8,
+		},
+		{
+			name:     "SWAP C ZFlag Set",
+			program:  []byte{0xCB, 0x31},
+			input:    SM83{C: 0x00},
+			expected: SM83{C: 0x00, F: 0b10000000},
+			cycles:   8,
+		},
+		{
+			name:     "SRL A",
+			program:  []byte{0xCB, 0x3F},
+			input:    SM83{A: 0x01},
+			expected: SM83{A: 0x00, F: 0b10010000},
+			cycles:   8,
+		},
+		{
+			name:     "SRL L",
+			program:  []byte{0xCB, 0x3D},
+			input:    SM83{L: 0xFF},
+			expected: SM83{L: 0x7F, F: 0b00010000},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestCBBit(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "BIT 7, H",
+			program:  []byte{0xCB, 0x7C},
+			input:    SM83{H: 0x80},
+			expected: SM83{H: 0x80, F: 0b00100000},
+			cycles:   8,
+		},
+		{
+			name:     "BIT 7, H ZFlag Set",
+			program:  []byte{0xCB, 0x7C},
+			input:    SM83{H: 0x7F},
+			expected: SM83{H: 0x7F, F: 0b10100000},
+			cycles:   8,
+		},
+		{
+			name:     "BIT 0, A keeps CFlag",
+			program:  []byte{0xCB, 0x47},
+			input:    SM83{A: 0x00, F: 0b01010000},
+			expected: SM83{A: 0x00, F: 0b10110000},
+			cycles:   8,
+		},
+		{
+			name:     "BIT 4, B",
+			program:  []byte{0xCB, 0x60},
+			input:    SM83{B: 0x10},
+			expected: SM83{B: 0x10, F: 0b00100000},
+			cycles:   8,
+		},
+		{
+			name:     "BIT 1, E",
+			program:  []byte{0xCB, 0x4B},
+			input:    SM83{E: 0xFD},
+			expected: SM83{E: 0xFD, F: 0b10100000},
+			cycles:   8,
+		},
+		{
+			name:     "RES 0, B",
+			program:  []byte{0xCB, 0x80},
+			input:    SM83{B: 0xFF},
+			expected: SM83{B: 0xFE},
+			cycles:   8,
+		},
+		{
+			name:     "RES 3, C",
+			program:  []byte{0xCB, 0x99},
+			input:    SM83{C: 0x08, F: 0b11110000},
+			expected: SM83{F: 0b11110000},
+			cycles:   8,
+		},
+		{
+			name:     "RES 7, A",
+			program:  []byte{0xCB, 0xBF},
+			input:    SM83{A: 0x80},
+			expected: SM83{A: 0x00},
+			cycles:   8,
+		},
+		{
+			name:     "SET 0, D",
+			program:  []byte{0xCB, 0xC2},
+			input:    SM83{D: 0x00},
+			expected: SM83{D: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "SET 5, L",
+			program:  []byte{0xCB, 0xED},
+			input:    SM83{L: 0x00, F: 0b10000000},
+			expected: SM83{L: 0x20, F: 0b10000000},
+			cycles:   8,
+		},
+		{
+			name:     "SET 7, A",
+			program:  []byte{0xCB, 0xFF},
+			input:    SM83{A: 0x7F},
+			expected: SM83{A: 0xFF},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.F != tc.expected.F {
+				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestCBHL(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		value    byte
+		expected byte
+		flags    byte
+		cycles   int
+	}{
+		{
+			name:     "RLC (HL)",
+			program:  []byte{0xCB, 0x06},
+			value:    0x85,
+			expected: 0x0B,
+			flags:    0b00010000,
+			cycles:   16,
+		},
+		{
+			name:     "SRL (HL)",
+			program:  []byte{0xCB, 0x3E},
+			value:    0x01,
+			expected: 0x00,
+			flags:    0b10010000,
+			cycles:   16,
+		},
+		{
+			name:     "SWAP (HL)",
+			program:  []byte{0xCB, 0x36},
+			value:    0x12,
+			expected: 0x21,
+			flags:    0b00000000,
+			cycles:   16,
+		},
+		{
+			name:     "BIT 2, (HL)",
+			program:  []byte{0xCB, 0x56},
+			value:    0x04,
+			expected: 0x04,
+			flags:    0b00100000,
+			cycles:   12,
+		},
+		{
+			name:     "RES 2, (HL)",
+			program:  []byte{0xCB, 0x96},
+			value:    0xFF,
+			expected: 0xFB,
+			flags:    0b00000000,
+			cycles:   16,
+		},
+		{
+			name:     "SET 6, (HL)",
+			program:  []byte{0xCB, 0xF6},
+			value:    0x00,
+			expected: 0x40,
+			flags:    0b00000000,
+			cycles:   16,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{H: 0xC0, L: 0x00, ram: memory.NewMemory()}
+			z.LoadProgram(tc.program, 0)
+			z.ram.Write(z.HL(), tc.value)
+
+			err := z.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if value := z.ram.Read(z.HL()); value != tc.expected {
+				t.Errorf("expected %x, got %x", tc.expected, value)
+			}
+
+			if z.F != tc.flags {
+				t.Errorf("expected flags %b, got %b", tc.flags, z.F)
+			}
+
+			if z.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, z.cycles)
+			}
+
+			if z.PC != 2 {
+				t.Errorf("expected PC %x, got %x", 2, z.PC)
+			}
+		})
+	}
+}
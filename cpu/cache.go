@@ -0,0 +1,235 @@
+package cpu
+
+import "github.com/danicat/gogoboy/memory"
+
+// maxBlockLength limits the number of instructions decoded into one block
+const maxBlockLength = 64
+
+// endsBlock reports whether an unprefixed opcode may change PC to anything
+// other than the next instruction
+func endsBlock(op byte) bool {
+	switch op {
+	case 0xC3, 0xC2, 0xCA, 0xD2, 0xDA, 0xE9, // JP
+		0x18, 0x20, 0x28, 0x30, 0x38, // JR
+		0xCD, 0xC4, 0xCC, 0xD4, 0xDC, // CALL
+		0xC9, 0xC0, 0xC8, 0xD0, 0xD8, 0xD9, // RET
+		0xC7, 0xCF, 0xD7, 0xDF, 0xE7, 0xEF, 0xF7, 0xFF, // RST
+		0x76, 0x10: // HALT, STOP
+		return true
+	}
+	return false
+}
+
+// decoded is an instruction whose opcode has already been looked up
+type decoded struct {
+	addr uint16
+	size uint16 // opcode bytes: 1, or 2 with the CB prefix
+	inst *opcode
+}
+
+// block is a straight-line run of instructions ending at a branch
+type block struct {
+	start, end uint16
+	insts      []decoded
+}
+
+// blockCache holds decoded blocks indexed by their start address. Memory is a
+// flat 64KB space without bank switching, so the address identifies the code.
+type blockCache struct {
+	blocks [65536]*block
+	refs   [65536]uint16 // number of cached blocks covering each address
+	cur    *block
+	next   int // index of the next instruction in cur
+}
+
+func newBlockCache() *blockCache {
+	return &blockCache{}
+}
+
+// SetBlockCache enables or disables executing from a cache of decoded basic
+// blocks. Writes to memory covered by a cached block invalidate it, so the
+// results are the same as the plain interpreter. The cache needs a
+// memory.Inspectable bus to see the writes, and stays disabled otherwise.
+func (z *SM83) SetBlockCache(enabled bool) {
+	m, ok := z.ram.(memory.Inspectable)
+	if z.cache != nil {
+		m.RemoveWriteHook(z.cache)
+		z.cache = nil
+	}
+	if !enabled || !ok {
+		return
+	}
+
+	z.cache = newBlockCache()
+	m.AddWriteHook(z.cache)
+}
+
+// dispatchCached executes the instruction at PC from the block cache
+func (z *SM83) dispatchCached() error {
+	if z.haltBug {
+		return z.dispatch()
+	}
+
+	d := z.cache.lookup(z)
+	if d == nil {
+		return z.dispatch()
+	}
+
+	// the opcode fetch still takes its M-cycles
+	for i := uint16(0); i < d.size; i++ {
+		z.tick()
+	}
+	z.PC += d.size
+	z.exec(d.inst)
+
+	return nil
+}
+
+// runBlock executes the block at PC until it ends, a branch is taken, the
+// cycle budget runs out or the plain interpreter is needed to handle
+// interrupts, low-power modes or the EI delay
+func (z *SM83) runBlock() error {
+	c := z.cache
+	if z.stopped || z.halted || z.locked || z.haltBug || z.imePending || z.ime && z.pendingInterrupts() != 0 || z.trace != nil || z.observers != nil || z.coverage != nil {
+		return z.step()
+	}
+
+	b := c.blocks[z.PC]
+	if b == nil {
+		b = c.compile(z, z.PC)
+		if b == nil {
+			return z.step()
+		}
+	}
+	c.cur = b
+
+	for i := range b.insts {
+		d := &b.insts[i]
+		if d.addr != z.PC || c.cur != b {
+			// a branch was taken or the block was invalidated
+			break
+		}
+
+		for j := uint16(0); j < d.size; j++ {
+			z.tick()
+		}
+		z.PC += d.size
+		d.inst.exec(z)
+
+		if z.fast {
+			z.flush()
+		}
+
+		if z.maxCycles != 0 && z.cycles >= z.maxCycles {
+			break
+		}
+		if z.imePending || z.halted || z.stopped || z.haltBug || z.ime && z.pendingInterrupts() != 0 {
+			break
+		}
+	}
+
+	c.cur = nil
+	return nil
+}
+
+// lookup returns the decoded instruction at PC, compiling a new block if
+// needed. It returns nil when no instruction can be decoded at PC.
+func (c *blockCache) lookup(z *SM83) *decoded {
+	if c.cur != nil && c.next < len(c.cur.insts) && c.cur.insts[c.next].addr == z.PC {
+		d := &c.cur.insts[c.next]
+		c.next++
+		return d
+	}
+
+	b := c.blocks[z.PC]
+	if b == nil {
+		b = c.compile(z, z.PC)
+		if b == nil {
+			c.cur = nil
+			return nil
+		}
+	}
+
+	c.cur = b
+	c.next = 1
+	return &b.insts[0]
+}
+
+// compile decodes the block starting at addr and adds it to the cache
+func (c *blockCache) compile(z *SM83, addr uint16) *block {
+	b := &block{start: addr}
+	pc := addr
+
+	for len(b.insts) < maxBlockLength {
+		op := z.peek(pc)
+		d := decoded{addr: pc, size: 1, inst: &opcodes[op]}
+		length := uint16(OpcodeTable[op].Length)
+		if op == 0xCB {
+			d.size, length = 2, 2
+			d.inst = &cbOpcodes[z.peek(pc+1)]
+		}
+
+		if d.inst.exec == nil {
+			break
+		}
+
+		if pc+length-1 < pc {
+			// stop before wrapping around the address space
+			break
+		}
+
+		b.insts = append(b.insts, d)
+		b.end = pc + length - 1
+		pc += length
+
+		if endsBlock(op) || b.end == 0xFFFF {
+			break
+		}
+	}
+
+	if len(b.insts) == 0 {
+		return nil
+	}
+
+	c.blocks[addr] = b
+	for a := int(b.start); a <= int(b.end); a++ {
+		c.refs[a]++
+	}
+
+	return b
+}
+
+// MemoryWritten removes every block covering a written address
+func (c *blockCache) MemoryWritten(addr uint16) {
+	if c.refs[addr] == 0 {
+		return
+	}
+
+	// a block covering addr starts at most maxBlockLength instructions of up
+	// to 3 bytes before it
+	first := int(addr) - maxBlockLength*3
+	if first < 0 {
+		first = 0
+	}
+
+	for start := first; start <= int(addr); start++ {
+		b := c.blocks[start]
+		if b == nil || addr > b.end {
+			continue
+		}
+
+		c.blocks[start] = nil
+		for a := int(b.start); a <= int(b.end); a++ {
+			c.refs[a]--
+		}
+	}
+
+	c.cur = nil
+}
+
+// reset empties the cache
+func (c *blockCache) reset() {
+	c.blocks = [65536]*block{}
+	c.refs = [65536]uint16{}
+	c.cur = nil
+}
@@ -0,0 +1,240 @@
+package cpu
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+// selfModifying patches its first instruction from INC B into INC C
+var selfModifying = []byte{
+	0x04,       // INC B
+	0x3E, 0x0C, // LD A, 0x0C (INC C)
+	0xEA, 0x00, 0xC0, // LD (0xC000), A
+	0xC3, 0x00, 0xC0, // JP 0xC000
+}
+
+// compareState fails the test if the two machines are in different states
+func compareState(t *testing.T, context string, plain, cached *SM83) {
+	t.Helper()
+
+	if plain.PC != cached.PC || plain.SP != cached.SP ||
+		plain.AF() != cached.AF() || plain.BC() != cached.BC() ||
+		plain.DE() != cached.DE() || plain.HL() != cached.HL() {
+		t.Fatalf("%s: registers diverged\nexpected PC=%04X SP=%04X AF=%04X BC=%04X DE=%04X HL=%04X\ngot      PC=%04X SP=%04X AF=%04X BC=%04X DE=%04X HL=%04X",
+			context, plain.PC, plain.SP, plain.AF(), plain.BC(), plain.DE(), plain.HL(),
+			cached.PC, cached.SP, cached.AF(), cached.BC(), cached.DE(), cached.HL())
+	}
+
+	if plain.cycles != cached.cycles {
+		t.Fatalf("%s: expected cycles=%d, got %d", context, plain.cycles, cached.cycles)
+	}
+
+	if plain.ime != cached.ime || plain.halted != cached.halted {
+		t.Fatalf("%s: expected IME=%v halted=%v, got IME=%v halted=%v",
+			context, plain.ime, plain.halted, cached.ime, cached.halted)
+	}
+}
+
+// compareMemory fails the test if the two machines have different memory
+func compareMemory(t *testing.T, plain, cached *SM83) {
+	t.Helper()
+
+	for a := 0; a < 0x10000; a++ {
+		if p, c := plain.ram.Read(uint16(a)), cached.ram.Read(uint16(a)); p != c {
+			t.Fatalf("expected (%04X)=%02X, got %02X", a, p, c)
+		}
+	}
+}
+
+// newPair returns two machines loaded with the same program, the second one
+// using the block cache
+func newPair(program []byte, addr uint16, setup func(z *SM83)) (plain, cached *SM83) {
+	plain = NewSM83()
+	cached = NewSM83()
+	cached.SetBlockCache(true)
+
+	for _, z := range []*SM83{plain, cached} {
+		z.LoadProgram(program, addr)
+		if setup != nil {
+			setup(z)
+		}
+	}
+
+	return plain, cached
+}
+
+// lockstep runs the same program with and without the block cache and checks
+// that both machines are in the same state after every step
+func lockstep(t *testing.T, program []byte, addr uint16, steps int, setup func(z *SM83)) (plain, cached *SM83) {
+	t.Helper()
+
+	plain, cached = newPair(program, addr, setup)
+
+	for i := 0; i < steps; i++ {
+		errPlain := plain.step()
+		errCached := cached.step()
+
+		if (errPlain == nil) != (errCached == nil) {
+			t.Fatalf("step %d: expected error %v, got %v", i, errPlain, errCached)
+		}
+
+		compareState(t, fmt.Sprintf("step %d", i), plain, cached)
+
+		if errPlain != nil {
+			break
+		}
+	}
+
+	compareMemory(t, plain, cached)
+
+	return plain, cached
+}
+
+// cacheTestPrograms returns the programs used to compare both interpreters
+func cacheTestPrograms(t *testing.T) []struct {
+	name    string
+	program []byte
+	addr    uint16
+	steps   int
+	setup   func(z *SM83)
+} {
+	bootROM, err := ioutil.ReadFile("testdata/DMG_ROM.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a VBlank handler at 0x0040 counting interrupts in B while the main
+	// loop halts and counts wake ups in C
+	interrupts := make([]byte, 0x110)
+	copy(interrupts[0x40:], []byte{0x04, 0xD9})
+	copy(interrupts[0x100:], []byte{
+		0x3E, 0x01, // LD A, 0x01
+		0xE0, 0xFF, // LDH (IE), A
+		0xFB,       // EI
+		0x76,       // HALT
+		0x0C,       // INC C
+		0x18, 0xFC, // JR -4
+	})
+
+	return []struct {
+		name    string
+		program []byte
+		addr    uint16
+		steps   int
+		setup   func(z *SM83)
+	}{
+		{
+			name:    "synthetic",
+			program: synthetic,
+			addr:    0x0100,
+			steps:   20000,
+		},
+		{
+			name:    "bootstrap ROM",
+			program: bootROM,
+			addr:    0,
+			steps:   50000,
+		},
+		{
+			name:    "self-modifying code",
+			program: selfModifying,
+			addr:    0xC000,
+			steps:   100,
+		},
+		{
+			name:    "interrupts",
+			program: interrupts,
+			addr:    0,
+			steps:   5000,
+			setup: func(z *SM83) {
+				z.PC = 0x0100
+				var elapsed int
+				z.OnTick(func(mcycles int) {
+					elapsed += mcycles
+					if elapsed >= 100 {
+						elapsed -= 100
+						z.RequestInterrupt(VBlank)
+					}
+				})
+			},
+		},
+	}
+}
+
+func TestBlockCacheLockstep(t *testing.T) {
+	for _, tc := range cacheTestPrograms(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			lockstep(t, tc.program, tc.addr, tc.steps, tc.setup)
+		})
+	}
+}
+
+func TestBlockCacheRun(t *testing.T) {
+	for _, tc := range cacheTestPrograms(t) {
+		for _, budget := range []int{4, 1000, 12345, 100000} {
+			t.Run(fmt.Sprintf("%s/%d", tc.name, budget), func(t *testing.T) {
+				plain, cached := newPair(tc.program, tc.addr, tc.setup)
+				plain.SetMaxCycles(budget)
+				cached.SetMaxCycles(budget)
+
+				errPlain := plain.Run()
+				errCached := cached.Run()
+				if (errPlain == nil) != (errCached == nil) {
+					t.Fatalf("expected error %v, got %v", errPlain, errCached)
+				}
+
+				compareState(t, "after Run", plain, cached)
+				compareMemory(t, plain, cached)
+			})
+		}
+	}
+}
+
+func TestBlockCacheSelfModifyingCode(t *testing.T) {
+	_, z := lockstep(t, selfModifying, 0xC000, 100, nil)
+
+	if z.B != 0x01 {
+		t.Errorf("expected B=%x, got %x", 0x01, z.B)
+	}
+	if z.C == 0x00 {
+		t.Errorf("expected the patched INC C to run")
+	}
+}
+
+func TestBlockCacheExternalWrite(t *testing.T) {
+	z := NewSM83()
+	z.SetBlockCache(true)
+	z.LoadProgram([]byte{0x04, 0x18, 0xFD}, 0xC000) // INC B, JR -3
+	z.B, z.C = 0, 0
+
+	for i := 0; i < 4; i++ {
+		z.step()
+	}
+	if z.B != 0x02 {
+		t.Fatalf("expected B=%x, got %x", 0x02, z.B)
+	}
+
+	// another component with its own write hook patches INC B into INC C
+	other := &writeCounter{}
+	z.Memory().AddWriteHook(other)
+	z.ram.Write(0xC000, 0x0C)
+
+	for i := 0; i < 4; i++ {
+		z.step()
+	}
+	if z.B != 0x02 || z.C != 0x02 {
+		t.Errorf("expected B=%x and C=%x, got B=%x and C=%x", 0x02, 0x02, z.B, z.C)
+	}
+	if other.writes != 1 {
+		t.Errorf("expected the other hook to see 1 write, got %d", other.writes)
+	}
+}
+
+type writeCounter struct {
+	writes int
+}
+
+func (w *writeCounter) MemoryWritten(addr uint16) {
+	w.writes++
+}
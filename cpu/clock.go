@@ -0,0 +1,85 @@
+package cpu
+
+// TickFunc is called as the CPU clock advances, with the number of M-cycles
+// that have elapsed since the previous call
+type TickFunc func(mcycles int)
+
+// OnTick subscribes f to the CPU clock. By default f is called once for every
+// memory access and internal delay, before the access happens. In fast mode f
+// is called once per instruction instead.
+func (z *SM83) OnTick(f TickFunc) {
+	z.tickers = append(z.tickers, f)
+	z.hooked = true
+}
+
+// SetFastMode selects whether tick subscribers are called once per
+// instruction (true) or once per M-cycle (false)
+func (z *SM83) SetFastMode(fast bool) {
+	z.fast = fast
+}
+
+// tick advances the clock by one M-cycle
+func (z *SM83) tick() {
+	z.cycles += 4
+
+	if z.fast {
+		z.elapsed++
+		return
+	}
+
+	if z.hooked {
+		z.notify(1)
+	}
+}
+
+// flush notifies the tick subscribers of the M-cycles elapsed in fast mode
+func (z *SM83) flush() {
+	if z.elapsed == 0 {
+		return
+	}
+
+	z.notify(z.elapsed)
+	z.elapsed = 0
+}
+
+// notify calls the tick subscribers and observers. It's kept apart from tick
+// so that tick can still be inlined.
+//
+//go:noinline
+func (z *SM83) notify(mcycles int) {
+	for _, f := range z.tickers {
+		f(mcycles)
+	}
+	for _, o := range z.observers {
+		o.CycleAdvance(z, mcycles)
+	}
+}
+
+// internal spends one M-cycle without accessing memory
+func (z *SM83) internal() {
+	z.tick()
+}
+
+func (z *SM83) read(addr uint16) byte {
+	z.tick()
+	if z.mem != nil {
+		return z.mem.Read(addr)
+	}
+	return z.ram.Read(addr)
+}
+
+func (z *SM83) write(addr uint16, val byte) {
+	z.tick()
+	if z.mem != nil {
+		z.mem.Write(addr, val)
+		return
+	}
+	z.ram.Write(addr, val)
+}
+
+// write16 writes a little-endian 16-bit value, low byte first
+func (z *SM83) write16(addr uint16, val uint16) {
+	hi, lo := split(val)
+	z.write(addr, lo)
+	z.write(addr+1, hi)
+}
@@ -0,0 +1,172 @@
+package cpu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// conditionFlags returns the flags that make the condition of a conditional
+// instruction true or false. ok is false for unconditional instructions.
+func conditionFlags(name string, taken bool) (f byte, ok bool) {
+	fields := strings.Fields(name)
+	if len(fields) < 2 {
+		return 0, false
+	}
+
+	switch fields[0] {
+	case "JP", "JR", "CALL", "RET":
+	default:
+		return 0, false
+	}
+
+	var takenF, notTakenF byte
+	switch strings.TrimSuffix(fields[1], ",") {
+	case "Z":
+		takenF, notTakenF = 0b10000000, 0b00000000
+	case "NZ":
+		takenF, notTakenF = 0b00000000, 0b10000000
+	case "C":
+		takenF, notTakenF = 0b00010000, 0b00000000
+	case "NC":
+		takenF, notTakenF = 0b00000000, 0b00010000
+	default:
+		return 0, false
+	}
+
+	if taken {
+		return takenF, true
+	}
+	return notTakenF, true
+}
+
+func TestOpcodeCycles(t *testing.T) {
+	run := func(t *testing.T, program []byte, f byte, expected int) {
+		z := &SM83{F: f, H: 0xC0, SP: 0xFFFE, ram: memory.NewMemory()}
+		z.LoadProgram(program, 0)
+
+		err := z.step()
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+
+		if z.cycles != expected {
+			t.Errorf("expected cycles=%d, got %d", expected, z.cycles)
+		}
+	}
+
+	for op, inst := range opcodes {
+		if inst.exec == nil {
+			continue
+		}
+
+		op, inst := byte(op), inst
+		t.Run(inst.name, func(t *testing.T) {
+			program := []byte{op, 0x00, 0x00}
+
+			f, conditional := conditionFlags(inst.name, false)
+			run(t, program, f, inst.cycles)
+
+			if conditional {
+				f, _ = conditionFlags(inst.name, true)
+				run(t, program, f, OpcodeTable[op].CyclesTaken)
+			}
+		})
+	}
+
+	for op, inst := range cbOpcodes {
+		op, inst := byte(op), inst
+		t.Run(inst.name, func(t *testing.T) {
+			run(t, []byte{0xCB, op}, 0, inst.cycles)
+		})
+	}
+}
+
+func TestOnTick(t *testing.T) {
+	tbl := []struct {
+		name     string
+		fast     bool
+		expected byte
+		calls    []int
+	}{
+		{
+			name:     "cycle accurate",
+			fast:     false,
+			expected: 0x03,
+			calls:    []int{1, 1, 1},
+		},
+		{
+			name:     "fast mode",
+			fast:     true,
+			expected: 0x00,
+			calls:    []int{3},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			z := &SM83{ram: m}
+			z.LoadProgram([]byte{0xF0, 0x04}, 0)
+			z.SetFastMode(tc.fast)
+
+			// a counter at 0xFF04 that advances every M-cycle
+			var calls []int
+			z.OnTick(func(mcycles int) {
+				calls = append(calls, mcycles)
+				m.Write(0xFF04, m.Read(0xFF04)+byte(mcycles))
+			})
+
+			err := z.step()
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			if z.A != tc.expected {
+				t.Errorf("expected A=%x, got %x", tc.expected, z.A)
+			}
+
+			if len(calls) != len(tc.calls) {
+				t.Fatalf("expected tick calls %v, got %v", tc.calls, calls)
+			}
+			for i := range calls {
+				if calls[i] != tc.calls[i] {
+					t.Errorf("expected tick calls %v, got %v", tc.calls, calls)
+				}
+			}
+
+			if v := m.Read(0xFF04); v != 0x03 {
+				t.Errorf("expected counter %x, got %x", 0x03, v)
+			}
+
+			if z.cycles != 12 {
+				t.Errorf("expected cycles=%d, got %d", 12, z.cycles)
+			}
+		})
+	}
+}
+
+func TestTickOrder(t *testing.T) {
+	m := memory.NewMemory()
+	z := &SM83{SP: 0xFFFE, ram: m}
+	z.LoadProgram([]byte{0xCD, 0x00, 0x02}, 0x0100)
+
+	// record the stack contents each M-cycle to check when the writes land
+	var stack []uint16
+	z.OnTick(func(mcycles int) {
+		stack = append(stack, m.Read16(0xFFFC))
+	})
+
+	z.step()
+
+	expected := []uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0100}
+	if len(stack) != len(expected) {
+		t.Fatalf("expected %d M-cycles, got %d", len(expected), len(stack))
+	}
+	for i := range expected {
+		if stack[i] != expected[i] {
+			t.Errorf("M-cycle %d: expected stack %x, got %x", i+1, expected[i], stack[i])
+		}
+	}
+}
@@ -1,16 +1,74 @@
 package cpu
 
-func (z *Z80) call(flag bool) {
-	hi := z.fetch()
-	lo := z.fetch()
-	addr := pair(hi, lo)
+// jump loads PC with an absolute address. Like every taken branch, loading
+// the new PC takes an extra M-cycle.
+func (z *SM83) jump() {
+	z.PC = z.fetch16()
+	z.internal()
+}
+
+func (z *SM83) jumpIf(flag bool) {
+	addr := z.fetch16()
 
 	if flag {
-		z.push(z.PC + 1)
 		z.PC = addr
+		z.internal()
+	}
+}
+
+// jumpRelative adds a signed displacement to the address of the next instruction
+func (z *SM83) jumpRelative() {
+	e := int8(z.fetch())
+	z.PC += uint16(e)
+	z.internal()
+}
+
+func (z *SM83) jumpRelativeIf(flag bool) {
+	e := int8(z.fetch())
+
+	if flag {
+		z.PC += uint16(e)
+		z.internal()
 	}
 }
 
-// func (z *Z80) jump(addr uint16) {
-// 	z.PC = addr
-// }
+func (z *SM83) call() {
+	addr := z.fetch16()
+	z.push(z.PC)
+	z.PC = addr
+}
+
+func (z *SM83) callIf(flag bool) {
+	addr := z.fetch16()
+
+	if flag {
+		z.push(z.PC)
+		z.PC = addr
+	}
+}
+
+func (z *SM83) ret() {
+	z.PC = z.pop()
+	z.internal()
+}
+
+// retIf spends an extra M-cycle evaluating the condition
+func (z *SM83) retIf(flag bool) {
+	z.internal()
+
+	if flag {
+		z.ret()
+	}
+}
+
+// reti returns from an interrupt handler and enables interrupts immediately
+func (z *SM83) reti() {
+	z.ret()
+	z.ime = true
+}
+
+// rst calls one of the eight fixed restart vectors
+func (z *SM83) rst(addr uint16) {
+	z.push(z.PC)
+	z.PC = addr
+}
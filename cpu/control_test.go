@@ -9,52 +9,64 @@ import (
 func TestCALLcc(t *testing.T) {
 	tbl := []testcase{
 		{
-			name:     "CALL Z, nn - Z",
-			program:  []byte{0x87, 0xCC, 0x01, 0x00},
-			input:    Z80{SP: 0xFFFE},
-			expected: Z80{F: 0b10000000, PC: 0x0100, SP: 0xFFFC},
+			name:       "CALL Z, nn - Z",
+			program:    []byte{0x87, 0xCC, 0x00, 0x01},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{F: 0b10000000, PC: 0x0100, SP: 0xFFFC},
+			expected16: 0x0004,
+			cycles:     28,
 		},
 		{
 			name:     "CALL Z, nn - NZ",
-			program:  []byte{0x87, 0xCC, 0x01, 0x00},
-			input:    Z80{A: 0x01, SP: 0xFFFE},
-			expected: Z80{A: 0x02, F: 0b00000000, PC: 0x0004, SP: 0xFFFE},
+			program:  []byte{0x87, 0xCC, 0x00, 0x01},
+			input:    SM83{A: 0x01, SP: 0xFFFE},
+			expected: SM83{A: 0x02, F: 0b00000000, PC: 0x0004, SP: 0xFFFE},
+			cycles:   16,
 		},
 		{
 			name:     "CALL NZ, nn - Z",
-			program:  []byte{0x87, 0xC4, 0x01, 0x00},
-			input:    Z80{SP: 0xFFFE},
-			expected: Z80{F: 0b10000000, PC: 0x0004, SP: 0xFFFE},
+			program:  []byte{0x87, 0xC4, 0x00, 0x01},
+			input:    SM83{SP: 0xFFFE},
+			expected: SM83{F: 0b10000000, PC: 0x0004, SP: 0xFFFE},
+			cycles:   16,
 		},
 		{
-			name:     "CALL NZ, nn - NZ",
-			program:  []byte{0x87, 0xC4, 0x01, 0x00},
-			input:    Z80{A: 0x01, SP: 0xFFFE},
-			expected: Z80{A: 0x02, F: 0b00000000, PC: 0x0100, SP: 0xFFFC},
+			name:       "CALL NZ, nn - NZ",
+			program:    []byte{0x87, 0xC4, 0x00, 0x01},
+			input:      SM83{A: 0x01, SP: 0xFFFE},
+			expected:   SM83{A: 0x02, F: 0b00000000, PC: 0x0100, SP: 0xFFFC},
+			expected16: 0x0004,
+			cycles:     28,
 		},
 		{
-			name:     "CALL C, nn - C",
-			program:  []byte{0x80, 0xDC, 0x01, 0x00},
-			input:    Z80{A: 0xFF, B: 0x01, SP: 0xFFFE},
-			expected: Z80{B: 0x01, F: 0b00110000, PC: 0x0100, SP: 0xFFFC},
+			name:       "CALL C, nn - C",
+			program:    []byte{0x80, 0xDC, 0x00, 0x01},
+			input:      SM83{A: 0xFF, B: 0x01, SP: 0xFFFE},
+			expected:   SM83{B: 0x01, F: 0b10110000, PC: 0x0100, SP: 0xFFFC},
+			expected16: 0x0004,
+			cycles:     28,
 		},
 		{
 			name:     "CALL C, nn - NC",
-			program:  []byte{0x80, 0xDC, 0x01, 0x00},
-			input:    Z80{A: 0x01, SP: 0xFFFE},
-			expected: Z80{A: 0x01, F: 0b00000000, PC: 0x0004, SP: 0xFFFE},
+			program:  []byte{0x80, 0xDC, 0x00, 0x01},
+			input:    SM83{A: 0x01, SP: 0xFFFE},
+			expected: SM83{A: 0x01, F: 0b00000000, PC: 0x0004, SP: 0xFFFE},
+			cycles:   16,
 		},
 		{
 			name:     "CALL NC, nn - C",
-			program:  []byte{0x80, 0xD4, 0x01, 0x00},
-			input:    Z80{A: 0xFF, B: 0x01, SP: 0xFFFE},
-			expected: Z80{B: 0x01, F: 0b00110000, PC: 0x0004, SP: 0xFFFE},
+			program:  []byte{0x80, 0xD4, 0x00, 0x01},
+			input:    SM83{A: 0xFF, B: 0x01, SP: 0xFFFE},
+			expected: SM83{B: 0x01, F: 0b10110000, PC: 0x0004, SP: 0xFFFE},
+			cycles:   16,
 		},
 		{
-			name:     "CALL NC, nn - NC",
-			program:  []byte{0x80, 0xD4, 0x01, 0x00},
-			input:    Z80{A: 0x01, SP: 0xFFFE},
-			expected: Z80{A: 0x01, F: 0b00000000, PC: 0x0100, SP: 0xFFFC},
+			name:       "CALL NC, nn - NC",
+			program:    []byte{0x80, 0xD4, 0x00, 0x01},
+			input:      SM83{A: 0x01, SP: 0xFFFE},
+			expected:   SM83{A: 0x01, F: 0b00000000, PC: 0x0100, SP: 0xFFFC},
+			expected16: 0x0004,
+			cycles:     28,
 		},
 	}
 
@@ -94,6 +106,487 @@ func TestCALLcc(t *testing.T) {
 			if tc.input.F != tc.expected.F {
 				t.Errorf("expected flags %b, got %b", tc.expected.F, tc.input.F)
 			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestJP(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "JP nn",
+			program:  []byte{0xC3, 0x50, 0x01},
+			input:    SM83{},
+			expected: SM83{PC: 0x0150},
+			cycles:   16,
+		},
+		{
+			name:     "JP NZ, nn - Z",
+			program:  []byte{0xC2, 0x50, 0x01},
+			input:    SM83{F: 0b10000000},
+			expected: SM83{F: 0b10000000, PC: 0x0003},
+			cycles:   12,
+		},
+		{
+			name:     "JP NZ, nn - NZ",
+			program:  []byte{0xC2, 0x50, 0x01},
+			input:    SM83{},
+			expected: SM83{PC: 0x0150},
+			cycles:   16,
+		},
+		{
+			name:     "JP Z, nn - Z",
+			program:  []byte{0xCA, 0x50, 0x01},
+			input:    SM83{F: 0b10000000},
+			expected: SM83{F: 0b10000000, PC: 0x0150},
+			cycles:   16,
+		},
+		{
+			name:     "JP Z, nn - NZ",
+			program:  []byte{0xCA, 0x50, 0x01},
+			input:    SM83{},
+			expected: SM83{PC: 0x0003},
+			cycles:   12,
+		},
+		{
+			name:     "JP NC, nn - C",
+			program:  []byte{0xD2, 0x50, 0x01},
+			input:    SM83{F: 0b00010000},
+			expected: SM83{F: 0b00010000, PC: 0x0003},
+			cycles:   12,
+		},
+		{
+			name:     "JP NC, nn - NC",
+			program:  []byte{0xD2, 0x50, 0x01},
+			input:    SM83{},
+			expected: SM83{PC: 0x0150},
+			cycles:   16,
+		},
+		{
+			name:     "JP C, nn - C",
+			program:  []byte{0xDA, 0x50, 0x01},
+			input:    SM83{F: 0b00010000},
+			expected: SM83{F: 0b00010000, PC: 0x0150},
+			cycles:   16,
+		},
+		{
+			name:     "JP C, nn - NC",
+			program:  []byte{0xDA, 0x50, 0x01},
+			input:    SM83{},
+			expected: SM83{PC: 0x0003},
+			cycles:   12,
+		},
+		{
+			name:     "JP (HL)",
+			program:  []byte{0xE9},
+			input:    SM83{H: 0xC0, L: 0x00},
+			expected: SM83{H: 0xC0, L: 0x00, PC: 0xC000},
+			cycles:   4,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.PC != tc.expected.PC {
+				t.Errorf("expected PC %x, got %x", tc.expected.PC, tc.input.PC)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestJR(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "JR e",
+			program:  []byte{0x18, 0x05},
+			input:    SM83{},
+			expected: SM83{PC: 0x0107},
+			cycles:   12,
+		},
+		{
+			name:     "JR e negative",
+			program:  []byte{0x18, 0xFE},
+			input:    SM83{},
+			expected: SM83{PC: 0x0100},
+			cycles:   12,
+		},
+		{
+			name:     "JR e backwards",
+			program:  []byte{0x18, 0x80},
+			input:    SM83{},
+			expected: SM83{PC: 0x0082},
+			cycles:   12,
+		},
+		{
+			name:     "JR NZ, e - Z",
+			program:  []byte{0x20, 0xFB},
+			input:    SM83{F: 0b10000000},
+			expected: SM83{F: 0b10000000, PC: 0x0102},
+			cycles:   8,
+		},
+		{
+			name:     "JR NZ, e - NZ",
+			program:  []byte{0x20, 0xFB},
+			input:    SM83{},
+			expected: SM83{PC: 0x00FD},
+			cycles:   12,
+		},
+		{
+			name:     "JR Z, e - Z",
+			program:  []byte{0x28, 0x7F},
+			input:    SM83{F: 0b10000000},
+			expected: SM83{F: 0b10000000, PC: 0x0181},
+			cycles:   12,
+		},
+		{
+			name:     "JR Z, e - NZ",
+			program:  []byte{0x28, 0x7F},
+			input:    SM83{},
+			expected: SM83{PC: 0x0102},
+			cycles:   8,
+		},
+		{
+			name:     "JR NC, e - C",
+			program:  []byte{0x30, 0x10},
+			input:    SM83{F: 0b00010000},
+			expected: SM83{F: 0b00010000, PC: 0x0102},
+			cycles:   8,
+		},
+		{
+			name:     "JR NC, e - NC",
+			program:  []byte{0x30, 0x10},
+			input:    SM83{},
+			expected: SM83{PC: 0x0112},
+			cycles:   12,
+		},
+		{
+			name:     "JR C, e - C",
+			program:  []byte{0x38, 0x10},
+			input:    SM83{F: 0b00010000},
+			expected: SM83{F: 0b00010000, PC: 0x0112},
+			cycles:   12,
+		},
+		{
+			name:     "JR C, e - NC",
+			program:  []byte{0x38, 0x10},
+			input:    SM83{},
+			expected: SM83{PC: 0x0102},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0x0100)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.PC != tc.expected.PC {
+				t.Errorf("expected PC %x, got %x", tc.expected.PC, tc.input.PC)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestCALL(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:       "CALL nn",
+			program:    []byte{0xCD, 0x34, 0x12},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x1234, SP: 0xFFFC},
+			expected16: 0x0103,
+			cycles:     24,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0x0100)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.PC != tc.expected.PC {
+				t.Errorf("expected PC %x, got %x", tc.expected.PC, tc.input.PC)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestRET(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "RET",
+			program:  []byte{0xC9},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   16,
+		},
+		{
+			name:     "RET NZ - Z",
+			program:  []byte{0xC0},
+			input:    SM83{F: 0b10000000, SP: 0xFFFC},
+			expected: SM83{PC: 0x0101, SP: 0xFFFC},
+			cycles:   8,
+		},
+		{
+			name:     "RET NZ - NZ",
+			program:  []byte{0xC0},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   20,
+		},
+		{
+			name:     "RET Z - Z",
+			program:  []byte{0xC8},
+			input:    SM83{F: 0b10000000, SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   20,
+		},
+		{
+			name:     "RET Z - NZ",
+			program:  []byte{0xC8},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x0101, SP: 0xFFFC},
+			cycles:   8,
+		},
+		{
+			name:     "RET NC - C",
+			program:  []byte{0xD0},
+			input:    SM83{F: 0b00010000, SP: 0xFFFC},
+			expected: SM83{PC: 0x0101, SP: 0xFFFC},
+			cycles:   8,
+		},
+		{
+			name:     "RET NC - NC",
+			program:  []byte{0xD0},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   20,
+		},
+		{
+			name:     "RET C - C",
+			program:  []byte{0xD8},
+			input:    SM83{F: 0b00010000, SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   20,
+		},
+		{
+			name:     "RET C - NC",
+			program:  []byte{0xD8},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x0101, SP: 0xFFFC},
+			cycles:   8,
+		},
+		{
+			name:     "RETI",
+			program:  []byte{0xD9},
+			input:    SM83{SP: 0xFFFC},
+			expected: SM83{PC: 0x1234, SP: 0xFFFE},
+			cycles:   16,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0x0100)
+			m.Write(0xFFFC, 0x34)
+			m.Write(0xFFFD, 0x12)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.PC != tc.expected.PC {
+				t.Errorf("expected PC %x, got %x", tc.expected.PC, tc.input.PC)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestRST(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:       "RST 00H",
+			program:    []byte{0xC7},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0000, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 08H",
+			program:    []byte{0xCF},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0008, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 10H",
+			program:    []byte{0xD7},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0010, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 18H",
+			program:    []byte{0xDF},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0018, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 20H",
+			program:    []byte{0xE7},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0020, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 28H",
+			program:    []byte{0xEF},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0028, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 30H",
+			program:    []byte{0xF7},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0030, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+		{
+			name:       "RST 38H",
+			program:    []byte{0xFF},
+			input:      SM83{SP: 0xFFFE},
+			expected:   SM83{PC: 0x0038, SP: 0xFFFC},
+			expected16: 0x0101,
+			cycles:     16,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0x0100)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.SP != tc.expected.SP {
+				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
+			}
+
+			if tc.input.PC != tc.expected.PC {
+				t.Errorf("expected PC %x, got %x", tc.expected.PC, tc.input.PC)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+
+			if tc.expected16 != 0 {
+				if ret := tc.input.pop(); ret != tc.expected16 {
+					t.Errorf("expected return address %x, got %x", tc.expected16, ret)
+				}
+			}
 		})
 	}
 }
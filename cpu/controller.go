@@ -0,0 +1,240 @@
+package cpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// ErrClosed is returned by the Controller methods after Close
+var ErrClosed = errors.New("controller closed")
+
+// controllerSlice is the number of cycles the controller runs between
+// checks for commands
+const controllerSlice = FrameCycles
+
+// Snapshot is a consistent copy of the CPU state, taken between instructions
+type Snapshot struct {
+	A, F, B, C, D, E, H, L byte
+	SP, PC                 uint16
+	IME, Halted, Stopped   bool
+	Cycles                 int
+	Running                bool  // the controller is running the CPU, rather than paused
+	Err                    error // the error that paused the controller, if any
+	Memory                 []byte
+}
+
+// Controller runs an SM83 on its own goroutine and takes commands from
+// other goroutines, such as a UI or a network server. The CPU and its memory
+// must only be accessed through the controller once it's created. The
+// controller starts paused.
+type Controller struct {
+	z         *SM83
+	commands  chan func()
+	updates   chan Snapshot
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// owned by the controller goroutine
+	running bool
+	err     error
+}
+
+// NewController starts a controller goroutine for z. Call Close to stop it.
+func NewController(z *SM83) *Controller {
+	c := &Controller{
+		z:        z,
+		commands: make(chan func()),
+		updates:  make(chan Snapshot, 1),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go c.loop()
+	return c
+}
+
+func (c *Controller) loop() {
+	defer close(c.done)
+	defer close(c.updates)
+
+	for {
+		if !c.running {
+			select {
+			case f := <-c.commands:
+				f()
+			case <-c.quit:
+				return
+			}
+			continue
+		}
+
+		select {
+		case f := <-c.commands:
+			f()
+			continue
+		case <-c.quit:
+			return
+		default:
+		}
+
+		_, err := c.z.RunContext(context.Background(), RunOptions{Cycles: controllerSlice})
+		if err != nil {
+			c.running = false
+			c.err = err
+		}
+		c.publish()
+	}
+}
+
+// do runs f on the controller goroutine and waits for it to finish. If f
+// panics, the panic is passed on to the caller and the controller keeps
+// running.
+func (c *Controller) do(f func()) error {
+	finished := make(chan interface{}, 1)
+	cmd := func() {
+		defer func() { finished <- recover() }()
+		f()
+	}
+
+	select {
+	case c.commands <- cmd:
+	case <-c.done:
+		return ErrClosed
+	}
+	if p := <-finished; p != nil {
+		panic(p)
+	}
+	return nil
+}
+
+// snapshot copies the CPU state. Memory is copied only when withMemory is
+// true.
+func (c *Controller) snapshot(withMemory bool) Snapshot {
+	z := c.z
+	s := Snapshot{
+		A: z.A, F: z.F, B: z.B, C: z.C, D: z.D, E: z.E, H: z.H, L: z.L,
+		SP: z.SP, PC: z.PC,
+		IME: z.ime, Halted: z.halted, Stopped: z.stopped,
+		Cycles:  z.cycles,
+		Running: c.running,
+		Err:     c.err,
+	}
+
+	if withMemory {
+		s.Memory = make([]byte, memory.MemorySize)
+		for i := range s.Memory {
+			s.Memory[i] = z.peek(uint16(i))
+		}
+	}
+	return s
+}
+
+// publish sends the current state to Updates, replacing a snapshot that
+// hasn't been received yet
+func (c *Controller) publish() {
+	s := c.snapshot(false)
+	select {
+	case <-c.updates:
+	default:
+	}
+	c.updates <- s
+}
+
+// Updates returns a channel that receives the latest state without memory
+// after every slice of emulation and every command that changes the state.
+// Snapshots that are not received in time are replaced by newer ones. The
+// channel is closed when the controller is closed.
+func (c *Controller) Updates() <-chan Snapshot {
+	return c.updates
+}
+
+// Resume starts running the CPU. It clears the error that paused it, if any.
+func (c *Controller) Resume() error {
+	return c.do(func() {
+		c.running = true
+		c.err = nil
+		c.publish()
+	})
+}
+
+// Pause stops running the CPU between instructions
+func (c *Controller) Pause() error {
+	return c.do(func() {
+		c.running = false
+		c.publish()
+	})
+}
+
+// Step pauses the controller and runs one instruction, or one wait step in a
+// low-power mode
+func (c *Controller) Step() (Instruction, error) {
+	var inst Instruction
+	var err error
+	if cerr := c.do(func() {
+		c.running = false
+		inst, err = c.z.Step()
+		c.err = err
+		c.publish()
+	}); cerr != nil {
+		return inst, cerr
+	}
+	return inst, err
+}
+
+// Reset resets the CPU registers. Memory is not changed.
+func (c *Controller) Reset() error {
+	return c.do(func() {
+		c.z.Reset()
+		c.err = nil
+		c.publish()
+	})
+}
+
+// ReadMemory returns n bytes starting at addr, wrapping around the address
+// space. Observers are not notified.
+func (c *Controller) ReadMemory(addr uint16, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid length %d", n)
+	}
+
+	data := make([]byte, n)
+	err := c.do(func() {
+		for i := range data {
+			data[i] = c.z.peek(addr + uint16(i))
+		}
+	})
+	return data, err
+}
+
+// WriteMemory writes data starting at addr, wrapping around the address
+// space
+func (c *Controller) WriteMemory(addr uint16, data []byte) error {
+	return c.do(func() {
+		for i, v := range data {
+			c.z.ram.Write(addr+uint16(i), v)
+		}
+	})
+}
+
+// Snapshot returns the CPU state including a copy of memory
+func (c *Controller) Snapshot() (Snapshot, error) {
+	var s Snapshot
+	err := c.do(func() {
+		s = c.snapshot(true)
+	})
+	return s, err
+}
+
+// Close stops the controller goroutine and waits for it to exit, closing the
+// Updates channel. The CPU can be used directly again afterwards.
+func (c *Controller) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+	})
+	<-c.done
+	return nil
+}
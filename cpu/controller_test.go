@@ -0,0 +1,258 @@
+package cpu
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCounterProgram increments the byte at C000 forever:
+// LD HL, C000; INC (HL); JR -3
+var memoryCounterProgram = []byte{0x21, 0x00, 0xC0, 0x34, 0x18, 0xFD}
+
+// waitForUpdate returns the first update that satisfies cond
+func waitForUpdate(t *testing.T, c *Controller, cond func(s Snapshot) bool) Snapshot {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case s := <-c.Updates():
+			if cond(s) {
+				return s
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for an update")
+		}
+	}
+}
+
+func TestControllerStep(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram(memoryCounterProgram, 0x0200)
+	c := NewController(z)
+	defer c.Close()
+
+	inst, err := c.Step()
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if inst.Address != 0x0200 || inst.Mnemonic != "LD" {
+		t.Errorf("expected LD at 0200, got %s", inst)
+	}
+
+	c.Step()
+	s, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if s.PC != 0x0204 || s.H != 0xC0 || s.L != 0x00 || s.Running {
+		t.Errorf("expected PC=0204 HL=C000 paused, got PC=%04X HL=%02X%02X running=%v", s.PC, s.H, s.L, s.Running)
+	}
+	if s.Memory[0xC000] != 1 {
+		t.Errorf("expected C000=01, got %02X", s.Memory[0xC000])
+	}
+}
+
+func TestControllerPauseResume(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram(memoryCounterProgram, 0x0200)
+	c := NewController(z)
+	defer c.Close()
+
+	// paused on creation
+	before, _ := c.Snapshot()
+	after, _ := c.Snapshot()
+	if before.Cycles != after.Cycles {
+		t.Errorf("expected no cycles to run before Resume, got %d", after.Cycles-before.Cycles)
+	}
+
+	if err := c.Resume(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	waitForUpdate(t, c, func(s Snapshot) bool { return s.Running && s.Cycles >= 2*FrameCycles })
+
+	if err := c.Pause(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	paused, _ := c.Snapshot()
+	again, _ := c.Snapshot()
+	if paused.Running || paused.Cycles != again.Cycles {
+		t.Errorf("expected the CPU to stay paused, got cycles %d then %d", paused.Cycles, again.Cycles)
+	}
+}
+
+func TestControllerMemory(t *testing.T) {
+	z := NewSM83()
+	c := NewController(z)
+	defer c.Close()
+
+	if err := c.WriteMemory(0xFFFE, []byte{1, 2, 3}); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	data, err := c.ReadMemory(0xFFFE, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	expected := []byte{1, 2, 3}
+	for i := range expected {
+		if data[i] != expected[i] {
+			t.Errorf("expected %X, got %X", expected, data)
+		}
+	}
+
+	if _, err := c.ReadMemory(0, -1); err == nil {
+		t.Errorf("expected an error for a negative length")
+	}
+}
+
+func TestControllerReset(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram(memoryCounterProgram, 0x0200)
+	c := NewController(z)
+	defer c.Close()
+
+	c.Step()
+	if err := c.Reset(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	s, _ := c.Snapshot()
+	if s.PC != 0 || s.Cycles != 0 || s.H != 0 {
+		t.Errorf("expected reset registers, got PC=%04X H=%02X cycles=%d", s.PC, s.H, s.Cycles)
+	}
+	if s.Memory[0x0200] != 0x21 {
+		t.Errorf("expected memory to be kept, got %02X at 0200", s.Memory[0x0200])
+	}
+}
+
+func TestControllerError(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram([]byte{0x00, 0xD3}, 0x0200)
+	c := NewController(z)
+	defer c.Close()
+
+	c.Resume()
+	s := waitForUpdate(t, c, func(s Snapshot) bool { return s.Err != nil })
+
+	var ie *IllegalOpcodeError
+	if !errors.As(s.Err, &ie) {
+		t.Errorf("expected IllegalOpcodeError, got: %v", s.Err)
+	}
+	if s.Running {
+		t.Errorf("expected the controller to pause on error")
+	}
+}
+
+func TestControllerClose(t *testing.T) {
+	c := NewController(NewSM83())
+	c.Close()
+
+	if err := c.Pause(); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got: %v", err)
+	}
+	if _, err := c.Snapshot(); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got: %v", err)
+	}
+
+	// closing twice is safe
+	c.Close()
+}
+
+func TestControllerPanic(t *testing.T) {
+	c := NewController(NewSM83())
+	defer c.Close()
+
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Errorf("expected the panic to reach the caller, got: %v", p)
+			}
+		}()
+		c.do(func() { panic("boom") })
+	}()
+
+	if _, err := c.Snapshot(); err != nil {
+		t.Errorf("expected the controller to keep running, got: %s", err)
+	}
+}
+
+func TestControllerCloseUpdates(t *testing.T) {
+	c := NewController(NewSM83())
+	c.Resume()
+
+	done := make(chan struct{})
+	go func() {
+		for range c.Updates() {
+		}
+		close(done)
+	}()
+
+	c.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected Updates to be closed")
+	}
+}
+
+// TestControllerConcurrent sends commands from several goroutines while the
+// CPU runs. Run it with -race.
+func TestControllerConcurrent(t *testing.T) {
+	z := NewSM83()
+	z.SetBlockCache(true)
+	z.LoadProgram(memoryCounterProgram, 0x0200)
+	c := NewController(z)
+	defer c.Close()
+	c.Resume()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				switch j % 4 {
+				case 0:
+					c.Snapshot()
+				case 1:
+					c.ReadMemory(0xC000, 1)
+				case 2:
+					c.WriteMemory(0xD000+uint16(i), []byte{byte(j)})
+				case 3:
+					c.Pause()
+					c.Resume()
+				}
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-c.Updates():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+
+	c.Pause()
+	s, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	for i := 0; i < 4; i++ {
+		if v := s.Memory[0xD000+i]; v != 18 {
+			t.Errorf("expected the last write to D00%d to be 18, got %d", i, v)
+		}
+	}
+	if s.Err != nil {
+		t.Errorf("expected no error, got: %s", s.Err)
+	}
+}
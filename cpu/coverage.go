@@ -0,0 +1,223 @@
+package cpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Coverage counts the opcodes executed by the CPU and the ones that failed
+// because they are unimplemented or illegal. One Coverage can be shared by
+// several runs to collect the totals for a set of ROMs.
+type Coverage struct {
+	Executed   [256]int // unprefixed opcodes, the CB prefix counts every CB instruction
+	CBExecuted [256]int
+	Failed     [256]int
+	CBFailed   [256]int
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{}
+}
+
+// SetCoverage records the opcodes executed into c. A nil c disables
+// recording. Recording bypasses the block cache so that every instruction is
+// seen.
+func (z *SM83) SetCoverage(c *Coverage) {
+	z.coverage = c
+}
+
+func (c *Coverage) record(prefix, op byte) {
+	if prefix == 0xCB {
+		c.Executed[prefix]++
+		c.CBExecuted[op]++
+		return
+	}
+	c.Executed[op]++
+}
+
+func (c *Coverage) fail(prefix, op byte) {
+	if prefix == 0xCB {
+		c.CBFailed[op]++
+		return
+	}
+	c.Failed[op]++
+}
+
+// Add adds the counts of o to c
+func (c *Coverage) Add(o *Coverage) {
+	for i := 0; i < 256; i++ {
+		c.Executed[i] += o.Executed[i]
+		c.CBExecuted[i] += o.CBExecuted[i]
+		c.Failed[i] += o.Failed[i]
+		c.CBFailed[i] += o.CBFailed[i]
+	}
+}
+
+// Grid marks used in coverage reports
+const (
+	CoverageExecuted      = '*' // implemented and executed
+	CoverageNotExecuted   = '.' // implemented, never executed
+	CoverageFailed        = '!' // unimplemented, the run tried to execute it
+	CoverageUnimplemented = '-' // unimplemented, never executed
+	CoverageIllegal       = ' ' // does not exist on hardware
+	CoverageIllegalHit    = 'X' // does not exist on hardware, the run tried to execute it
+)
+
+// CoverageReport summarises the coverage of the unprefixed and CB prefixed
+// opcode tables
+type CoverageReport struct {
+	Opcodes   CoverageTable `json:"opcodes"`
+	CBOpcodes CoverageTable `json:"cb_opcodes"`
+}
+
+// CoverageTable summarises one opcode table. Grid has one string per high
+// nibble of the opcode, with one mark per low nibble.
+type CoverageTable struct {
+	Name          string           `json:"name"`
+	Legal         int              `json:"legal"`
+	Implemented   int              `json:"implemented"`
+	Executed      int              `json:"executed"`
+	Unimplemented int              `json:"unimplemented_hit"`
+	IllegalHit    int              `json:"illegal_hit"`
+	Grid          [16]string       `json:"grid"`
+	Entries       []OpcodeCoverage `json:"entries"`
+}
+
+// OpcodeCoverage is the coverage of one opcode
+type OpcodeCoverage struct {
+	Opcode      string `json:"opcode"`
+	Mnemonic    string `json:"mnemonic"`
+	Implemented bool   `json:"implemented"`
+	Illegal     bool   `json:"illegal,omitempty"`
+	Executed    int    `json:"executed"`
+	Failed      int    `json:"failed,omitempty"`
+}
+
+// Mark returns the grid mark for the opcode
+func (o OpcodeCoverage) Mark() byte {
+	switch {
+	case o.Illegal && o.Failed > 0:
+		return CoverageIllegalHit
+	case o.Illegal:
+		return CoverageIllegal
+	case o.Implemented && o.Executed > 0:
+		return CoverageExecuted
+	case o.Implemented:
+		return CoverageNotExecuted
+	case o.Failed > 0:
+		return CoverageFailed
+	}
+	return CoverageUnimplemented
+}
+
+// Report summarises the counts against the opcode tables
+func (c *Coverage) Report() CoverageReport {
+	return CoverageReport{
+		Opcodes:   coverageTable("Opcodes", 0, &opcodes, &OpcodeTable, &c.Executed, &c.Failed),
+		CBOpcodes: coverageTable("CB opcodes", 0xCB, &cbOpcodes, &CBOpcodeTable, &c.CBExecuted, &c.CBFailed),
+	}
+}
+
+func coverageTable(name string, prefix byte, table *[256]opcode, info *[256]OpcodeInfo, executed, failed *[256]int) CoverageTable {
+	t := CoverageTable{Name: name, Entries: make([]OpcodeCoverage, 256)}
+
+	var grid [256]byte
+	for i := range table {
+		op := byte(i)
+		e := OpcodeCoverage{
+			Opcode:      opcodeString(prefix, op),
+			Mnemonic:    info[op].Name(),
+			Implemented: table[op].exec != nil || prefix == 0 && op == 0xCB,
+			Illegal:     info[op].Illegal,
+			Executed:    executed[op],
+			Failed:      failed[op],
+		}
+		t.Entries[i] = e
+		grid[i] = e.Mark()
+
+		if e.Illegal {
+			if e.Failed > 0 {
+				t.IllegalHit++
+			}
+			continue
+		}
+		t.Legal++
+		if e.Implemented {
+			t.Implemented++
+		}
+		if e.Implemented && e.Executed > 0 {
+			t.Executed++
+		}
+		if !e.Implemented && e.Failed > 0 {
+			t.Unimplemented++
+		}
+	}
+
+	for row := range t.Grid {
+		t.Grid[row] = string(grid[row*16 : row*16+16])
+	}
+	return t
+}
+
+// WriteText writes the report as a 16x16 grid per table, followed by the
+// unimplemented and illegal opcodes the run tried to execute, most frequent
+// first
+func (r CoverageReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, t := range []CoverageTable{r.Opcodes, r.CBOpcodes} {
+		t.writeText(&b)
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "%c executed  %c not executed  %c unimplemented, hit  %c unimplemented  '%c' illegal  %c illegal, hit\n",
+		CoverageExecuted, CoverageNotExecuted, CoverageFailed, CoverageUnimplemented, CoverageIllegal, CoverageIllegalHit)
+
+	var failed []OpcodeCoverage
+	for _, t := range []CoverageTable{r.Opcodes, r.CBOpcodes} {
+		for _, e := range t.Entries {
+			if e.Failed > 0 {
+				failed = append(failed, e)
+			}
+		}
+	}
+	sort.SliceStable(failed, func(i, j int) bool { return failed[i].Failed > failed[j].Failed })
+
+	if len(failed) > 0 {
+		b.WriteString("\nFailed opcodes:\n")
+		for _, e := range failed {
+			fmt.Fprintf(&b, "  %-5s  %-12s  %d\n", e.Opcode, e.Mnemonic, e.Failed)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (t CoverageTable) writeText(b *strings.Builder) {
+	fmt.Fprintf(b, "%s: %d of %d implemented, %d executed, %d unimplemented hit, %d illegal hit\n",
+		t.Name, t.Implemented, t.Legal, t.Executed, t.Unimplemented, t.IllegalHit)
+
+	b.WriteString("  ")
+	for col := 0; col < 16; col++ {
+		fmt.Fprintf(b, " x%X", col)
+	}
+	b.WriteString("\n")
+
+	for row, marks := range t.Grid {
+		fmt.Fprintf(b, "%Xx", row)
+		for i := range marks {
+			fmt.Fprintf(b, "  %c", marks[i])
+		}
+		b.WriteString("\n")
+	}
+}
+
+// WriteJSON writes the report as indented JSON
+func (r CoverageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,183 @@
+package cpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	// LD A, 1; SWAP A; NOP; NOP; JR -2
+	program := []byte{0x3E, 0x01, 0xCB, 0x37, 0x00, 0x00, 0x18, 0xFE}
+
+	for _, cached := range []bool{false, true} {
+		name := "plain"
+		if cached {
+			name = "block cache"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			z := NewSM83()
+			z.SetBlockCache(cached)
+			z.LoadProgram(program, 0x0100)
+			c := NewCoverage()
+			z.SetCoverage(c)
+			z.SetMaxCycles(z.cycles + 24 + 12*3)
+
+			if err := z.Run(); err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			expected := map[byte]int{0x3E: 1, 0xCB: 1, 0x00: 2, 0x18: 3}
+			for op, n := range c.Executed {
+				if n != expected[byte(op)] {
+					t.Errorf("opcode %02X: expected %d executions, got %d", op, expected[byte(op)], n)
+				}
+			}
+			if c.CBExecuted[0x37] != 1 {
+				t.Errorf("opcode CB 37: expected 1 execution, got %d", c.CBExecuted[0x37])
+			}
+		})
+	}
+}
+
+func TestCoverageFailed(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram([]byte{0x00, 0xD3}, 0x0100)
+	c := NewCoverage()
+	z.SetCoverage(c)
+
+	z.step()
+	if err := z.step(); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if c.Failed[0xD3] != 1 {
+		t.Errorf("expected 1 failure for D3, got %d", c.Failed[0xD3])
+	}
+
+	// every legal opcode is implemented, so an unimplemented one is recorded
+	// directly and checked against a copy of the table without it
+	z.fail(0x0200, 0xCB, 0x37)
+	cb := cbOpcodes
+	cb[0x37].exec = nil
+
+	r := c.Report()
+	r.CBOpcodes = coverageTable("CB opcodes", 0xCB, &cb, &CBOpcodeTable, &c.CBExecuted, &c.CBFailed)
+
+	if r.Opcodes.IllegalHit != 1 || r.Opcodes.Unimplemented != 0 {
+		t.Errorf("expected 1 illegal and 0 unimplemented opcodes hit, got %d and %d", r.Opcodes.IllegalHit, r.Opcodes.Unimplemented)
+	}
+	if mark := r.Opcodes.Grid[0xD][3]; mark != CoverageIllegalHit {
+		t.Errorf("expected mark %q for D3, got %q", CoverageIllegalHit, mark)
+	}
+	if r.CBOpcodes.Unimplemented != 1 || r.CBOpcodes.Implemented != 255 {
+		t.Errorf("expected 255 implemented and 1 unimplemented hit, got %d and %d", r.CBOpcodes.Implemented, r.CBOpcodes.Unimplemented)
+	}
+	if mark := r.CBOpcodes.Grid[3][7]; mark != CoverageFailed {
+		t.Errorf("expected mark %q for CB 37, got %q", CoverageFailed, mark)
+	}
+
+	var b bytes.Buffer
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	for _, e := range []string{
+		"Opcodes: 245 of 245 implemented, 1 executed, 0 unimplemented hit, 1 illegal hit\n",
+		"Failed opcodes:\n",
+		"  CB 37  SWAP A        1\n",
+	} {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("expected %q in the report, got:\n%s", e, b.String())
+		}
+	}
+}
+
+func TestCoverageReport(t *testing.T) {
+	c := NewCoverage()
+	c.Executed[0x00] = 3
+	c.Executed[0x01] = 1
+
+	other := NewCoverage()
+	other.Executed[0x00] = 2
+	other.CBExecuted[0xFF] = 1
+	c.Add(other)
+
+	r := c.Report()
+
+	if r.Opcodes.Legal != 245 || r.Opcodes.Executed != 2 {
+		t.Errorf("expected 2 of 245 opcodes executed, got %d of %d", r.Opcodes.Executed, r.Opcodes.Legal)
+	}
+	if r.CBOpcodes.Legal != 256 || r.CBOpcodes.Executed != 1 {
+		t.Errorf("expected 1 of 256 CB opcodes executed, got %d of %d", r.CBOpcodes.Executed, r.CBOpcodes.Legal)
+	}
+
+	e := r.Opcodes.Entries[0x00]
+	expected := OpcodeCoverage{Opcode: "00", Mnemonic: "NOP", Implemented: true, Executed: 5}
+	if e != expected {
+		t.Errorf("expected %+v, got %+v", expected, e)
+	}
+
+	tbl := []struct {
+		row      int
+		expected string
+	}{
+		{0x0, "**.............."},
+		{0xD, "... ....... . .."},
+		{0xE, "...  ......   .."},
+	}
+	for _, tc := range tbl {
+		if row := r.Opcodes.Grid[tc.row]; row != tc.expected {
+			t.Errorf("row %X: expected %q, got %q", tc.row, tc.expected, row)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	header := []string{
+		"Opcodes: 245 of 245 implemented, 2 executed, 0 unimplemented hit, 0 illegal hit",
+		"   x0 x1 x2 x3 x4 x5 x6 x7 x8 x9 xA xB xC xD xE xF",
+		"0x  *  *  .  .  .  .  .  .  .  .  .  .  .  .  .  .",
+	}
+	for i := range header {
+		if lines[i] != header[i] {
+			t.Errorf("line %d: expected %q, got %q", i+1, header[i], lines[i])
+		}
+	}
+
+	b.Reset()
+	if err := r.WriteJSON(&b); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	var decoded CoverageReport
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Errorf("expected the JSON report to round trip")
+	}
+}
+
+// TestCoverageREADME keeps the status in the README in line with the report
+func TestCoverageREADME(t *testing.T) {
+	readme, err := ioutil.ReadFile("../README.md")
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	r := NewCoverage().Report()
+	for _, line := range []string{
+		fmt.Sprintf("- CPU: %d of %d opcodes implemented (the other %d of 256 are illegal)\n", r.Opcodes.Implemented, r.Opcodes.Legal, 256-r.Opcodes.Legal),
+		fmt.Sprintf("- CPU: %d of %d CB prefixed opcodes implemented\n", r.CBOpcodes.Implemented, r.CBOpcodes.Legal),
+	} {
+		if !strings.Contains(string(readme), line) {
+			t.Errorf("expected README.md to contain %q", line)
+		}
+	}
+}
@@ -1,24 +1,54 @@
 package cpu
 
 import (
-	"fmt"
+	"io"
 
 	"github.com/danicat/gogoboy/memory"
 )
 
-// Z80 holds the internal representation of the Z80 CPU registers
-type Z80 struct {
+// SM83 holds the internal representation of the Sharp SM83 CPU registers, the
+// Game Boy core. It's often described as a Z80, but it lacks IX/IY, the shadow
+// registers, the I/O port space and the ED/DD/FD prefixes. The z80 package
+// implements the Zilog Z80.
+type SM83 struct {
 	PC, SP                 uint16
 	A, F, B, C, D, E, H, L byte
-	ram                    *memory.Memory
+	ram                    memory.Bus
+	mem                    *memory.Memory // ram, if it's a Memory, to skip the interface calls
 	cycles                 int
 	maxCycles              int
+	ime, imePending        bool
+	halted, haltBug        bool
+	stopped                bool
+	tickers                []TickFunc
+	fast                   bool
+	elapsed                int
+	cache                  *blockCache
+	lockup, locked         bool
+	trace                  io.Writer
+	traceFrom, traceTo     uint16
+	observers              []Observer
+	coverage               *Coverage
+	hooked                 bool // there are tick subscribers or observers
 }
 
-// NewZ80 creates a new Z80 instance
-func NewZ80() *Z80 {
-	r := memory.NewMemory()
-	return &Z80{
+// Z80 is the former name of SM83.
+//
+// Deprecated: use SM83, or the z80 package for a Zilog Z80.
+type Z80 = SM83
+
+// NewSM83 creates a new SM83 instance with its own memory
+func NewSM83() *SM83 {
+	return NewSM83WithBus(memory.NewMemory())
+}
+
+// NewSM83WithBus creates a new SM83 instance that runs against bus. Peeking,
+// memory observers and the block cache need a memory.Inspectable bus: with
+// other buses the tools that inspect memory read it with Read, observers only
+// see CPU events and the block cache stays disabled.
+func NewSM83WithBus(bus memory.Bus) *SM83 {
+	m, _ := bus.(*memory.Memory)
+	return &SM83{
 		A:   0x01,
 		F:   0xB0,
 		B:   0x00,
@@ -29,21 +59,60 @@ func NewZ80() *Z80 {
 		L:   0x4D,
 		PC:  0x100,
 		SP:  0xFFFE,
-		ram: r,
+		ram: bus,
+		mem: m,
 	}
 }
 
+// NewZ80 creates a new SM83 instance.
+//
+// Deprecated: use NewSM83.
+func NewZ80() *SM83 {
+	return NewSM83()
+}
+
+// Memory returns the memory the CPU is attached to, or nil if it runs against
+// another kind of bus
+func (z *SM83) Memory() *memory.Memory {
+	m, _ := z.ram.(*memory.Memory)
+	return m
+}
+
+// Bus returns the bus the CPU is attached to
+func (z *SM83) Bus() memory.Bus {
+	return z.ram
+}
+
+// peek reads addr without side effects, if the bus supports it
+func (z *SM83) peek(addr uint16) byte {
+	if z.mem != nil {
+		return z.mem.Peek(addr)
+	}
+	return memory.Peek(z.ram, addr)
+}
+
 // SetMaxCycles set the maximum number of cycles for the Run function to process. Max cycles of 0 indicates no limit.
-func (z *Z80) SetMaxCycles(max int) {
+func (z *SM83) SetMaxCycles(max int) {
 	z.maxCycles = max
 }
 
-func (z *Z80) LoadProgram(p []byte, addr uint16) {
+// LoadProgram copies p to addr and points PC at it. Memory is cleared first;
+// other buses only get p written to them.
+func (z *SM83) LoadProgram(p []byte, addr uint16) {
 	z.PC = addr
-	z.ram.LoadProgram(p, addr)
+	if m, ok := z.ram.(*memory.Memory); ok {
+		m.LoadProgram(p, addr)
+	} else {
+		for i, b := range p {
+			z.ram.Write(addr+uint16(i), b)
+		}
+	}
+	if z.cache != nil {
+		z.cache.reset()
+	}
 }
 
-func (z *Z80) Reset() {
+func (z *SM83) Reset() {
 	z.PC = 0
 	z.SP = 0xFFFE
 	z.A = 0
@@ -54,11 +123,23 @@ func (z *Z80) Reset() {
 	z.E = 0
 	z.H = 0
 	z.L = 0
+	z.ime = false
+	z.imePending = false
+	z.halted = false
+	z.haltBug = false
+	z.stopped = false
+	z.locked = false
+	z.cycles = 0
 }
 
-func (z *Z80) Run() error {
+func (z *SM83) Run() error {
 	for z.cycles < z.maxCycles || z.maxCycles == 0 {
-		err := z.step()
+		var err error
+		if z.cache != nil {
+			err = z.runBlock()
+		} else {
+			err = z.step()
+		}
 		if err != nil {
 			return err
 		}
@@ -67,22 +148,128 @@ func (z *Z80) Run() error {
 }
 
 // step runs one instruction at a time
-func (z *Z80) step() error {
+func (z *SM83) step() error {
+	err := z.execute()
+	if z.fast {
+		z.flush()
+	}
+	return err
+}
+
+func (z *SM83) execute() error {
+	if z.wait() {
+		return nil
+	}
+	return z.next()
+}
+
+// next runs the instruction at PC, from the block cache when enabled
+func (z *SM83) next() error {
+	if z.trace != nil {
+		if err := z.traceState(); err != nil {
+			return err
+		}
+	}
+
+	if z.observers != nil {
+		return z.observe()
+	}
+
+	if z.cache != nil && z.coverage == nil {
+		return z.dispatchCached()
+	}
+	return z.dispatch()
+}
+
+// observe runs the instruction at PC notifying the observers
+func (z *SM83) observe() error {
+	pc := z.PC
+	for _, o := range z.observers {
+		o.BeforeInstruction(z)
+	}
+
+	// the block cache skips the opcode fetch reads, so it's not used here
+	if err := z.dispatch(); err != nil {
+		return err
+	}
+
+	for _, o := range z.observers {
+		o.AfterInstruction(z, pc)
+	}
+	return nil
+}
+
+// wait handles the low-power modes and interrupt dispatch. It returns true
+// when no instruction should run in this step.
+func (z *SM83) wait() bool {
+	// time keeps passing while the CPU is in a low-power mode or locked up
+	if z.stopped || z.locked {
+		z.internal()
+		return true
+	}
+
+	if z.halted {
+		if z.pendingInterrupts() == 0 {
+			z.internal()
+			return true
+		}
+		z.halted = false
+	}
+
+	return z.interrupt()
+}
+
+// dispatch fetches, decodes and executes the instruction at PC
+func (z *SM83) dispatch() error {
+	pc := z.PC
 	op := z.fetch()
+	if z.haltBug {
+		z.PC--
+		z.haltBug = false
+	}
 
-	inst, ok := opcodes[op]
-	if !ok {
-		return fmt.Errorf("opcode not implemented: %X", op)
+	var prefix byte
+	inst := &opcodes[op]
+	if op == 0xCB {
+		prefix = op
+		op = z.fetch()
+		inst = &cbOpcodes[op]
 	}
 
-	z.cycles += inst.cycles
-	inst.exec(z)
+	if inst.exec == nil {
+		return z.fail(pc, prefix, op)
+	}
+
+	if z.coverage != nil {
+		z.coverage.record(prefix, op)
+	}
+	z.exec(inst)
 
 	return nil
 }
 
-func (z *Z80) fetch() byte {
-	op := z.ram.Read(z.PC)
+// exec runs a decoded instruction
+func (z *SM83) exec(inst *opcode) {
+	enable := z.imePending
+
+	inst.exec(z)
+
+	// EI takes effect only after the instruction that follows it
+	if enable && z.imePending {
+		z.ime = true
+		z.imePending = false
+	}
+}
+
+func (z *SM83) fetch() byte {
+	op := z.read(z.PC)
 	z.PC++
 	return op
 }
+
+// fetch16 reads a little-endian 16-bit immediate
+func (z *SM83) fetch16() uint16 {
+	lo := z.fetch()
+	hi := z.fetch()
+	return pair(hi, lo)
+}
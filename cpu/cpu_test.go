@@ -3,14 +3,17 @@ package cpu
 import (
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
+
+	"github.com/danicat/gogoboy/memory"
 )
 
 type testcase struct {
 	name       string
 	program    []byte
-	input      Z80
-	expected   Z80
+	input      SM83
+	expected   SM83
 	input16    uint16
 	expected16 uint16
 	cycles     int
@@ -19,7 +22,7 @@ type testcase struct {
 func TestFetch(t *testing.T) {
 	input := []byte{0xDE, 0xAD}
 	expected := byte(0xDE)
-	z := NewZ80()
+	z := NewSM83()
 	z.LoadProgram(input, 0)
 	op := z.fetch()
 	if op != expected {
@@ -35,11 +38,16 @@ func TestCycleLimit(t *testing.T) {
 		{
 			name:     "LD A,0x1F LD B,0x21 ADD A,B",
 			program:  []byte{0x3E, 0x1F, 0x06, 0x21, 0x80},
-			expected: Z80{A: 0x40, F: 0b00100000, cycles: 100},
+			expected: SM83{A: 0x40, F: 0b00100000, cycles: 100},
+		},
+		{
+			name:     "INC A JR NZ,-3",
+			program:  []byte{0x3C, 0x20, 0xFD},
+			expected: SM83{A: 0x07, F: 0b00000000, cycles: 100},
 		},
 	}
 
-	z := NewZ80()
+	z := NewSM83()
 
 	for _, tc := range tbl {
 		t.Run(tc.name, func(t *testing.T) {
@@ -71,7 +79,7 @@ func TestBootstrapROM(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	z := NewZ80()
+	z := NewSM83()
 	z.LoadProgram(data, 0)
 	z.SetMaxCycles(1000)
 	err = z.Run()
@@ -79,3 +87,107 @@ func TestBootstrapROM(t *testing.T) {
 		t.Errorf("expected no errors, got: %s", err)
 	}
 }
+
+func TestStep(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		expected Instruction
+	}{
+		{"NOP", []byte{0x00}, Instruction{Bytes: []byte{0x00}, Mnemonic: "NOP", Length: 1, Cycles: 4}},
+		{"LD A, n", []byte{0x3E, 0x12}, Instruction{Bytes: []byte{0x3E, 0x12}, Mnemonic: "LD", Operands: []string{"A", "$12"}, Length: 2, Cycles: 8}},
+		{"LD (nn), A", []byte{0xEA, 0x34, 0xC0}, Instruction{Bytes: []byte{0xEA, 0x34, 0xC0}, Mnemonic: "LD", Operands: []string{"($C034)", "A"}, Length: 3, Cycles: 16}},
+		{"LDH A, (n)", []byte{0xF0, 0x44}, Instruction{Bytes: []byte{0xF0, 0x44}, Mnemonic: "LDH", Operands: []string{"A", "($44)"}, Length: 2, Cycles: 12}},
+		{"JR e", []byte{0x18, 0xFE}, Instruction{Bytes: []byte{0x18, 0xFE}, Mnemonic: "JR", Operands: []string{"-2"}, Length: 2, Cycles: 12}},
+		{"JR NZ, e", []byte{0x20, 0x05}, Instruction{Bytes: []byte{0x20, 0x05}, Mnemonic: "JR", Operands: []string{"NZ", "+5"}, Length: 2, Cycles: 12}},
+		{"LD HL, SP+e", []byte{0xF8, 0xFF}, Instruction{Bytes: []byte{0xF8, 0xFF}, Mnemonic: "LD", Operands: []string{"HL", "SP-1"}, Length: 2, Cycles: 12}},
+		{"SWAP A", []byte{0xCB, 0x37}, Instruction{Bytes: []byte{0xCB, 0x37}, Mnemonic: "SWAP", Operands: []string{"A"}, Length: 2, Cycles: 8}},
+		{"CALL nn", []byte{0xCD, 0x00, 0x02}, Instruction{Bytes: []byte{0xCD, 0x00, 0x02}, Mnemonic: "CALL", Operands: []string{"$0200"}, Length: 3, Cycles: 24}},
+	}
+
+	for _, cached := range []bool{false, true} {
+		for _, tc := range tbl {
+			t.Run(tc.name, func(t *testing.T) {
+				z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+				z.SetBlockCache(cached)
+				z.LoadProgram(tc.program, 0x0100)
+
+				inst, err := z.Step()
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+
+				tc.expected.Address = 0x0100
+				if !reflect.DeepEqual(inst, tc.expected) {
+					t.Errorf("expected %+v, got %+v", tc.expected, inst)
+				}
+				if z.Cycles() != tc.expected.Cycles {
+					t.Errorf("expected cycles=%d, got %d", tc.expected.Cycles, z.Cycles())
+				}
+			})
+		}
+	}
+}
+
+func TestStepNoInstruction(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0x76, 0x00}, 0x0100)
+
+	inst, err := z.Step()
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if inst.String() != "HALT" {
+		t.Errorf("expected HALT, got %q", inst)
+	}
+
+	// halted
+	inst, _ = z.Step()
+	if inst.Length != 0 || inst.Address != 0x0101 || inst.Cycles != 4 {
+		t.Errorf("expected halted step at 0101 taking 4 cycles, got %+v", inst)
+	}
+
+	// woken up by an interrupt
+	z.ime = true
+	z.ram.Write(IE, 0xFF)
+	z.RequestInterrupt(Timer)
+	inst, _ = z.Step()
+	if inst.Length != 0 || inst.Address != 0x0101 || inst.Cycles != 20 {
+		t.Errorf("expected interrupt dispatch at 0101 taking 20 cycles, got %+v", inst)
+	}
+	if z.PC != 0x0050 {
+		t.Errorf("expected PC %x, got %x", 0x0050, z.PC)
+	}
+}
+
+// flatBus is a Bus that supports nothing else
+type flatBus [memory.MemorySize]byte
+
+func (b *flatBus) Read(addr uint16) byte       { return b[addr] }
+func (b *flatBus) Write(addr uint16, val byte) { b[addr] = val }
+
+func TestBus(t *testing.T) {
+	bus := &flatBus{}
+	z := NewSM83WithBus(bus)
+	z.SetBlockCache(true)
+	if z.Memory() != nil {
+		t.Errorf("expected no Memory for another kind of bus")
+	}
+
+	// LD A, 0x42; LD (0xC000), A
+	z.LoadProgram([]byte{0x3E, 0x42, 0xEA, 0x00, 0xC0}, 0x0200)
+	z.SetMaxCycles(z.cycles + 8 + 16)
+	if err := z.Run(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	if bus[0xC000] != 0x42 {
+		t.Errorf("expected 42 at C000, got %02X", bus[0xC000])
+	}
+	if z.cache != nil {
+		t.Errorf("expected the block cache to stay disabled")
+	}
+	if inst := z.decode(0x0202); inst.Mnemonic != "LD" || inst.String() != "LD ($C000), A" {
+		t.Errorf("expected LD ($C000), A at 0202, got %s", inst)
+	}
+}
@@ -0,0 +1,242 @@
+// Package cputest provides partial CPU state expectations and scenario
+// running for tests of the cpu package and of programs running on it
+package cputest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/danicat/gogoboy/cpu"
+	"github.com/danicat/gogoboy/memory"
+)
+
+// State is a partial CPU state. Only the fields that are set are applied or
+// checked, so zero values can be told apart from fields left out.
+type State struct {
+	A, F, B, C, D, E, H, L *byte
+	AF, BC, DE, HL, SP, PC *uint16
+
+	// Flags is an alternative to F, with one character for each of Z, N, H
+	// and C: the letter if set, - or 0 if reset or ? if either. Flags left
+	// out at the end are not applied or checked. Other characters panic.
+	Flags string
+
+	Memory map[uint16]byte
+	Cycles *int
+	IME    *bool
+	Halted *bool
+}
+
+// Byte returns a pointer to v, for the 8-bit fields of State
+func Byte(v byte) *byte {
+	return &v
+}
+
+// Word returns a pointer to v, for the 16-bit fields of State
+func Word(v uint16) *uint16 {
+	return &v
+}
+
+// Int returns a pointer to v, for State.Cycles
+func Int(v int) *int {
+	return &v
+}
+
+// Bool returns a pointer to v, for State.IME and State.Halted
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Apply sets the registers and memory in s on z. Cycles, IME and Halted can
+// only be checked.
+func Apply(z *cpu.SM83, s State) {
+	set8 := func(r *byte, v *byte) {
+		if v != nil {
+			*r = *v
+		}
+	}
+	set8(&z.A, s.A)
+	set8(&z.F, s.F)
+	set8(&z.B, s.B)
+	set8(&z.C, s.C)
+	set8(&z.D, s.D)
+	set8(&z.E, s.E)
+	set8(&z.H, s.H)
+	set8(&z.L, s.L)
+
+	if s.AF != nil {
+		z.SetAF(*s.AF)
+	}
+	if s.BC != nil {
+		z.SetBC(*s.BC)
+	}
+	if s.DE != nil {
+		z.SetDE(*s.DE)
+	}
+	if s.HL != nil {
+		z.SetHL(*s.HL)
+	}
+	if s.SP != nil {
+		z.SP = *s.SP
+	}
+	if s.PC != nil {
+		z.PC = *s.PC
+	}
+
+	if s.Flags != "" {
+		set, known := parseFlags(s.Flags)
+		z.F = z.F&^known | set
+	}
+
+	for addr, v := range s.Memory {
+		z.Bus().Write(addr, v)
+	}
+}
+
+// Diff compares z with the fields set in expected and returns one line per
+// difference, or an empty string if they match
+func Diff(z *cpu.SM83, expected State) string {
+	var b strings.Builder
+
+	check8 := func(name string, e *byte, got byte) {
+		if e != nil && *e != got {
+			fmt.Fprintf(&b, "%s: expected %02X, got %02X\n", name, *e, got)
+		}
+	}
+	check16 := func(name string, e *uint16, got uint16) {
+		if e != nil && *e != got {
+			fmt.Fprintf(&b, "%s: expected %04X, got %04X\n", name, *e, got)
+		}
+	}
+
+	check8("A", expected.A, z.A)
+	if expected.F != nil && *expected.F != z.F {
+		fmt.Fprintf(&b, "F: expected %02X (%s), got %02X (%s)\n", *expected.F, cpu.FlagString(*expected.F), z.F, cpu.FlagString(z.F))
+	}
+	check8("B", expected.B, z.B)
+	check8("C", expected.C, z.C)
+	check8("D", expected.D, z.D)
+	check8("E", expected.E, z.E)
+	check8("H", expected.H, z.H)
+	check8("L", expected.L, z.L)
+	check16("AF", expected.AF, z.AF())
+	check16("BC", expected.BC, z.BC())
+	check16("DE", expected.DE, z.DE())
+	check16("HL", expected.HL, z.HL())
+	check16("SP", expected.SP, z.SP)
+	check16("PC", expected.PC, z.PC)
+
+	if expected.Flags != "" && !matchFlags(expected.Flags, z.F) {
+		fmt.Fprintf(&b, "flags: expected %s, got %s\n", expected.Flags, cpu.FlagString(z.F))
+	}
+
+	addrs := make([]int, 0, len(expected.Memory))
+	for addr := range expected.Memory {
+		addrs = append(addrs, int(addr))
+	}
+	sort.Ints(addrs)
+	for _, addr := range addrs {
+		e, got := expected.Memory[uint16(addr)], memory.Peek(z.Bus(), uint16(addr))
+		if e != got {
+			fmt.Fprintf(&b, "(%04X): expected %02X, got %02X\n", addr, e, got)
+		}
+	}
+
+	if expected.Cycles != nil && *expected.Cycles != z.Cycles() {
+		fmt.Fprintf(&b, "cycles: expected %d, got %d\n", *expected.Cycles, z.Cycles())
+	}
+	if expected.IME != nil && *expected.IME != z.IME() {
+		fmt.Fprintf(&b, "IME: expected %v, got %v\n", *expected.IME, z.IME())
+	}
+	if expected.Halted != nil && *expected.Halted != z.Halted() {
+		fmt.Fprintf(&b, "halted: expected %v, got %v\n", *expected.Halted, z.Halted())
+	}
+
+	return b.String()
+}
+
+// parseFlags returns the flags a pattern sets and the flags it gives a value
+// to, set or reset. It panics on an invalid pattern rather than guessing.
+func parseFlags(pattern string) (set, known byte) {
+	if len(pattern) > 4 {
+		panic(fmt.Sprintf("cputest: invalid flags %q: more than 4 flags", pattern))
+	}
+
+	for i, c := range pattern {
+		mask := byte(0x80) >> uint(i)
+		switch c {
+		case rune("ZNHC"[i]):
+			set |= mask
+			known |= mask
+		case '-', '0':
+			known |= mask
+		case '?':
+		default:
+			panic(fmt.Sprintf("cputest: invalid flags %q: %q at position %d, expected %c, -, 0 or ?", pattern, c, i+1, "ZNHC"[i]))
+		}
+	}
+	return set, known
+}
+
+func matchFlags(pattern string, f byte) bool {
+	set, known := parseFlags(pattern)
+	return f&known == set
+}
+
+// Scenario runs a program on a fresh machine and checks the final state
+type Scenario struct {
+	Name     string
+	Program  []byte
+	Origin   uint16 // where Program is loaded and execution starts
+	Setup    State  // applied after loading the program
+	Steps    int    // instructions to run, 1 if not set
+	Expected State
+}
+
+// NewMachine returns a CPU with all registers cleared, SP at 0xFFFE and
+// program loaded at origin
+func NewMachine(program []byte, origin uint16) *cpu.SM83 {
+	z := cpu.NewSM83()
+	z.Reset()
+	z.LoadProgram(program, origin)
+	return z
+}
+
+// Exec runs s and returns the machine and the differences from the expected
+// state
+func Exec(s Scenario) (z *cpu.SM83, diff string, err error) {
+	z = NewMachine(s.Program, s.Origin)
+	Apply(z, s.Setup)
+
+	steps := s.Steps
+	if steps == 0 {
+		steps = 1
+	}
+	for i := 0; i < steps; i++ {
+		if _, err := z.Step(); err != nil {
+			return z, "", fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	return z, Diff(z, s.Expected), nil
+}
+
+// Run runs each scenario as a subtest of t
+func Run(t *testing.T, scenarios []Scenario) {
+	t.Helper()
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			t.Helper()
+			_, diff, err := Exec(s)
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if diff != "" {
+				t.Errorf("state mismatch:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package cputest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, []Scenario{
+		{
+			Name:     "LD A, n",
+			Program:  []byte{0x3E, 0x12},
+			Expected: State{A: Byte(0x12), PC: Word(0x0002), Cycles: Int(8)},
+		},
+		{
+			Name:     "ADD A, B sets Z, H and C",
+			Program:  []byte{0x80},
+			Setup:    State{A: Byte(0xFF), B: Byte(0x01)},
+			Expected: State{A: Byte(0x00), Flags: "Z-HC"},
+		},
+		{
+			Name:     "LD (HL), A",
+			Program:  []byte{0x77},
+			Origin:   0x0100,
+			Setup:    State{A: Byte(0x42), HL: Word(0xC000)},
+			Expected: State{Memory: map[uint16]byte{0xC000: 0x42}, PC: Word(0x0101)},
+		},
+		{
+			Name:     "multi-step loop",
+			Program:  []byte{0x06, 0x03, 0x05, 0x20, 0xFD},
+			Steps:    7,
+			Expected: State{B: Byte(0x00), Flags: "ZN??", PC: Word(0x0005)},
+		},
+		{
+			Name:     "setup flags and memory",
+			Program:  []byte{0x8E},
+			Setup:    State{A: Byte(0x01), Flags: "---C", HL: Word(0xC000), Memory: map[uint16]byte{0xC000: 0x01}},
+			Expected: State{AF: Word(0x0300)},
+		},
+	})
+}
+
+func TestDiff(t *testing.T) {
+	z, diff, err := Exec(Scenario{
+		Program: []byte{0x3C},
+		Setup:   State{A: Byte(0x0F), HL: Word(0xC000)},
+		Expected: State{
+			A:      Byte(0x11),
+			F:      Byte(0xA0),
+			HL:     Word(0xC000),
+			Flags:  "-N??",
+			Memory: map[uint16]byte{0xC001: 0x01, 0xC000: 0x02},
+			Cycles: Int(8),
+			IME:    Bool(true),
+			Halted: Bool(false),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if z.A != 0x10 {
+		t.Errorf("expected A=%x, got %x", 0x10, z.A)
+	}
+
+	expected := `A: expected 11, got 10
+F: expected A0 (Z-H-), got 20 (--H-)
+flags: expected -N??, got --H-
+(C000): expected 02, got 00
+(C001): expected 01, got 00
+cycles: expected 8, got 4
+IME: expected true, got false
+`
+	if diff != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, diff)
+	}
+}
+
+func TestExecError(t *testing.T) {
+	_, _, err := Exec(Scenario{Program: []byte{0x00, 0xD3}, Steps: 2})
+	if err == nil || !strings.Contains(err.Error(), "step 2") {
+		t.Errorf("expected an error on step 2, got: %v", err)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	tbl := []struct {
+		pattern string
+		f       byte
+		match   bool
+	}{
+		{"Z-H-", 0xA0, true},
+		{"Z0H0", 0xA0, true},
+		{"Z00C", 0x90, true},
+		{"Z00C", 0xB0, false},
+		{"??HC", 0x30, true},
+		{"-N", 0x4F, true},
+	}
+	for _, tc := range tbl {
+		if got := matchFlags(tc.pattern, tc.f); got != tc.match {
+			t.Errorf("%s: expected match=%v for %02X, got %v", tc.pattern, tc.match, tc.f, got)
+		}
+	}
+
+	z := NewMachine(nil, 0)
+	z.F = 0xF0
+	Apply(z, State{Flags: "Z0H-"})
+	if z.F != 0xA0 {
+		t.Errorf("expected F=A0, got %02X", z.F)
+	}
+}
+
+func TestFlagsInvalid(t *testing.T) {
+	for _, pattern := range []string{"1000", "N---", "Z-H-C", "z---"} {
+		t.Run(pattern, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %q to panic", pattern)
+				}
+			}()
+			matchFlags(pattern, 0)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cpu
+
+import "fmt"
+
+// UnimplementedOpcodeError is returned when an instruction that exists on
+// hardware is not implemented by gogoboy
+type UnimplementedOpcodeError struct {
+	PC     uint16 // address of the instruction, including any prefix
+	Prefix byte   // 0xCB for prefixed instructions, 0 otherwise
+	Opcode byte
+	Cycles int // value of the cycle counter when the error happened
+}
+
+func (e *UnimplementedOpcodeError) Error() string {
+	return fmt.Sprintf("opcode not implemented: %s at %04X (cycle %d)", opcodeString(e.Prefix, e.Opcode), e.PC, e.Cycles)
+}
+
+// IllegalOpcodeError is returned when the CPU fetches one of the opcodes
+// that do not exist on hardware and lockup mode is disabled
+type IllegalOpcodeError struct {
+	PC     uint16 // address of the instruction
+	Prefix byte   // always 0, illegal opcodes are never prefixed
+	Opcode byte
+	Cycles int // value of the cycle counter when the error happened
+}
+
+func (e *IllegalOpcodeError) Error() string {
+	return fmt.Sprintf("illegal opcode: %s at %04X (cycle %d)", opcodeString(e.Prefix, e.Opcode), e.PC, e.Cycles)
+}
+
+func opcodeString(prefix, op byte) string {
+	if prefix != 0 {
+		return fmt.Sprintf("%02X %02X", prefix, op)
+	}
+	return fmt.Sprintf("%02X", op)
+}
+
+// illegal reports whether an unprefixed opcode does not exist on hardware
+func illegal(op byte) bool {
+	switch op {
+	case 0xD3, 0xDB, 0xDD, 0xE3, 0xE4, 0xEB, 0xEC, 0xED, 0xF4, 0xFC, 0xFD:
+		return true
+	}
+	return false
+}
+
+// fail handles an opcode at pc without an implementation. Illegal opcodes
+// lock the CPU up in lockup mode.
+func (z *SM83) fail(pc uint16, prefix, op byte) error {
+	if z.coverage != nil {
+		z.coverage.fail(prefix, op)
+	}
+	if prefix == 0 && illegal(op) {
+		if z.lockup {
+			z.locked = true
+			return nil
+		}
+		return &IllegalOpcodeError{PC: pc, Opcode: op, Cycles: z.cycles}
+	}
+	return &UnimplementedOpcodeError{PC: pc, Prefix: prefix, Opcode: op, Cycles: z.cycles}
+}
+
+// SetIllegalOpcodeLockup selects what happens when the CPU fetches an
+// illegal opcode. When enabled the CPU freezes like hardware does, until it
+// is reset. Otherwise Run returns an IllegalOpcodeError.
+func (z *SM83) SetIllegalOpcodeLockup(enabled bool) {
+	z.lockup = enabled
+}
+
+// Locked returns true when the CPU has frozen on an illegal opcode
+func (z *SM83) Locked() bool {
+	return z.locked
+}
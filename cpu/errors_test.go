@@ -0,0 +1,139 @@
+package cpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+var illegalOpcodes = []byte{0xD3, 0xDB, 0xDD, 0xE3, 0xE4, 0xEB, 0xEC, 0xED, 0xF4, 0xFC, 0xFD}
+
+func TestIllegalOpcodeError(t *testing.T) {
+	for _, op := range illegalOpcodes {
+		z := &SM83{ram: memory.NewMemory()}
+		z.LoadProgram([]byte{0x00, op}, 0x0100)
+
+		if err := z.step(); err != nil {
+			t.Fatalf("%02X: expected no error, got: %s", op, err)
+		}
+
+		err := z.step()
+		var ie *IllegalOpcodeError
+		if !errors.As(err, &ie) {
+			t.Fatalf("%02X: expected IllegalOpcodeError, got: %v", op, err)
+		}
+		if ie.PC != 0x0101 {
+			t.Errorf("%02X: expected PC %x, got %x", op, 0x0101, ie.PC)
+		}
+		if ie.Opcode != op || ie.Prefix != 0 {
+			t.Errorf("%02X: expected opcode %02X, got %02X %02X", op, op, ie.Prefix, ie.Opcode)
+		}
+		if ie.Cycles != 8 {
+			t.Errorf("%02X: expected cycles=%d, got %d", op, 8, ie.Cycles)
+		}
+
+		var ue *UnimplementedOpcodeError
+		if errors.As(err, &ue) {
+			t.Errorf("%02X: illegal opcode reported as unimplemented", op)
+		}
+	}
+}
+
+// TestUnimplementedOpcodeError checks the error returned for opcodes without
+// an implementation. Every legal opcode is implemented, so fail is called
+// directly.
+func TestUnimplementedOpcodeError(t *testing.T) {
+	tbl := []struct {
+		name     string
+		prefix   byte
+		op       byte
+		expected string
+	}{
+		{"unprefixed", 0x00, 0x04, "opcode not implemented: 04 at 0200 (cycle 4)"},
+		{"CB prefixed", 0xCB, 0x37, "opcode not implemented: CB 37 at 0200 (cycle 4)"},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{ram: memory.NewMemory(), cycles: 4}
+
+			err := z.fail(0x0200, tc.prefix, tc.op)
+			var ue *UnimplementedOpcodeError
+			if !errors.As(err, &ue) {
+				t.Fatalf("expected UnimplementedOpcodeError, got: %v", err)
+			}
+			if ue.PC != 0x0200 {
+				t.Errorf("expected PC %x, got %x", 0x0200, ue.PC)
+			}
+			if ue.Prefix != tc.prefix || ue.Opcode != tc.op {
+				t.Errorf("expected opcode %02X %02X, got %02X %02X", tc.prefix, tc.op, ue.Prefix, ue.Opcode)
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, err.Error())
+			}
+
+			var ie *IllegalOpcodeError
+			if errors.As(err, &ie) {
+				t.Errorf("unimplemented opcode reported as illegal")
+			}
+		})
+	}
+}
+
+func TestIllegalOpcodeLockup(t *testing.T) {
+	for _, op := range illegalOpcodes {
+		z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+		z.SetIllegalOpcodeLockup(true)
+		z.LoadProgram([]byte{op, 0x3C}, 0)
+
+		for i := 0; i < 4; i++ {
+			if err := z.step(); err != nil {
+				t.Fatalf("%02X: expected no error, got: %s", op, err)
+			}
+
+			// not even an interrupt can wake the CPU up
+			z.ime = true
+			z.ram.Write(IE, 0xFF)
+			z.RequestInterrupt(Joypad)
+		}
+
+		if !z.Locked() {
+			t.Errorf("%02X: expected CPU to be locked", op)
+		}
+		if z.PC != 0x0001 {
+			t.Errorf("%02X: expected PC %x, got %x", op, 0x0001, z.PC)
+		}
+		if z.A != 0 {
+			t.Errorf("%02X: expected A=%x, got %x", op, 0, z.A)
+		}
+		if z.cycles != 16 {
+			t.Errorf("%02X: expected cycles=%d, got %d", op, 16, z.cycles)
+		}
+
+		z.Reset()
+		if z.Locked() {
+			t.Errorf("%02X: expected reset to clear lockup", op)
+		}
+	}
+}
+
+func TestIllegalOpcodeLockupRun(t *testing.T) {
+	for _, cached := range []bool{false, true} {
+		z := &SM83{ram: memory.NewMemory()}
+		z.SetIllegalOpcodeLockup(true)
+		z.SetBlockCache(cached)
+		z.LoadProgram([]byte{0x3C, 0x3C, 0xDD, 0x3C}, 0)
+		z.SetMaxCycles(100)
+
+		if err := z.Run(); err != nil {
+			t.Fatalf("cached=%v: expected no error, got: %s", cached, err)
+		}
+		if !z.Locked() {
+			t.Errorf("cached=%v: expected CPU to be locked", cached)
+		}
+		if z.A != 2 {
+			t.Errorf("cached=%v: expected A=%x, got %x", cached, 2, z.A)
+		}
+	}
+}
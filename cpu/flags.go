@@ -1,49 +1,60 @@
 package cpu
 
-func (z *Z80) SetZFlag() {
+// FlagString returns the flags in f as letters, e.g. Z-H-
+func FlagString(f byte) string {
+	b := []byte("----")
+	for i, l := range "ZNHC" {
+		if f&(0x80>>uint(i)) != 0 {
+			b[i] = byte(l)
+		}
+	}
+	return string(b)
+}
+
+func (z *SM83) SetZFlag() {
 	z.F |= 0b10000000
 }
 
-func (z *Z80) ResetZFlag() {
+func (z *SM83) ResetZFlag() {
 	z.F &= 0b01111111
 }
 
-func (z *Z80) ZFlag() bool {
+func (z *SM83) ZFlag() bool {
 	return z.F&0b10000000 > 0
 }
 
-func (z *Z80) SetNFlag() {
+func (z *SM83) SetNFlag() {
 	z.F |= 0b01000000
 }
 
-func (z *Z80) ResetNFlag() {
+func (z *SM83) ResetNFlag() {
 	z.F &= 0b10111111
 }
 
-func (z *Z80) NFlag() bool {
+func (z *SM83) NFlag() bool {
 	return z.F&0b01000000 > 0
 }
 
-func (z *Z80) SetHFlag() {
+func (z *SM83) SetHFlag() {
 	z.F |= 0b00100000
 }
 
-func (z *Z80) ResetHFlag() {
+func (z *SM83) ResetHFlag() {
 	z.F &= 0b11011111
 }
 
-func (z *Z80) HFlag() bool {
+func (z *SM83) HFlag() bool {
 	return z.F&0b00100000 > 0
 }
 
-func (z *Z80) SetCFlag() {
+func (z *SM83) SetCFlag() {
 	z.F |= 0b00010000
 }
 
-func (z *Z80) ResetCFlag() {
+func (z *SM83) ResetCFlag() {
 	z.F &= 0b11101111
 }
 
-func (z *Z80) CFlag() bool {
+func (z *SM83) CFlag() bool {
 	return z.F&0b00010000 > 0
 }
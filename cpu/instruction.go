@@ -0,0 +1,93 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Instruction describes an instruction executed by Step
+type Instruction struct {
+	Address  uint16   // address of the first byte, including any prefix
+	Bytes    []byte   // raw bytes: prefix, opcode and operands
+	Mnemonic string   // e.g. "LD"
+	Operands []string // e.g. ["A", "$12"], with immediate values filled in
+	Length   int      // number of bytes
+	Cycles   int      // T-cycles taken, including any interrupt dispatch
+}
+
+// String returns the instruction in assembly syntax, e.g. "LD A, $12"
+func (i Instruction) String() string {
+	if len(i.Operands) == 0 {
+		return i.Mnemonic
+	}
+	return i.Mnemonic + " " + strings.Join(i.Operands, ", ")
+}
+
+// Step runs a single instruction and returns its details. When no
+// instruction runs because the CPU is halted, stopped, locked up or
+// dispatching an interrupt, the returned Instruction has zero Length and only
+// Address and Cycles are set.
+func (z *SM83) Step() (Instruction, error) {
+	start := z.cycles
+	inst := Instruction{Address: z.PC}
+
+	var err error
+	if !z.wait() {
+		inst = z.decode(z.PC)
+		err = z.next()
+	}
+
+	if z.fast {
+		z.flush()
+	}
+	inst.Cycles = z.cycles - start
+	return inst, err
+}
+
+// Cycles returns the number of T-cycles elapsed since the last reset
+func (z *SM83) Cycles() int {
+	return z.cycles
+}
+
+// decode reads the instruction at addr without advancing the clock
+func (z *SM83) decode(addr uint16) Instruction {
+	op := z.peek(addr)
+	info := &OpcodeTable[op]
+	if op == 0xCB {
+		info = &CBOpcodeTable[z.peek(addr+1)]
+	}
+
+	inst := Instruction{Address: addr, Mnemonic: info.Mnemonic, Length: info.Length, Bytes: make([]byte, info.Length)}
+	inst.Bytes[0] = op
+
+	// with the HALT bug the byte after HALT is read twice
+	next := addr + 1
+	if z.haltBug {
+		next = addr
+	}
+	for i := 1; i < info.Length; i++ {
+		inst.Bytes[i] = z.peek(next)
+		next++
+	}
+
+	for _, o := range info.Operands {
+		inst.Operands = append(inst.Operands, operand(o, inst.Bytes))
+	}
+	return inst
+}
+
+// operand replaces the immediate placeholder in o with the value from the
+// instruction bytes
+func operand(o string, b []byte) string {
+	switch {
+	case strings.Contains(o, "nn"):
+		return strings.Replace(o, "nn", fmt.Sprintf("$%04X", uint16(b[2])<<8|uint16(b[1])), 1)
+	case o == "n" || o == "(n)":
+		return strings.Replace(o, "n", fmt.Sprintf("$%02X", b[1]), 1)
+	case o == "e":
+		return fmt.Sprintf("%+d", int8(b[1]))
+	case strings.HasSuffix(o, "+e"):
+		return strings.TrimSuffix(o, "+e") + fmt.Sprintf("%+d", int8(b[1]))
+	}
+	return o
+}
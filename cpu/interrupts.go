@@ -0,0 +1,89 @@
+package cpu
+
+// Interrupt identifies one of the five interrupt lines. The value is the
+// bit position of the line in the IE and IF registers.
+type Interrupt byte
+
+const (
+	VBlank Interrupt = iota
+	LCDStat
+	Timer
+	Serial
+	Joypad
+)
+
+const (
+	// IF is the address of the interrupt flag register
+	IF uint16 = 0xFF0F
+	// IE is the address of the interrupt enable register
+	IE uint16 = 0xFFFF
+)
+
+// RequestInterrupt raises the given interrupt line by setting its bit in IF.
+// A joypad event also wakes the CPU from STOP.
+func (z *SM83) RequestInterrupt(i Interrupt) {
+	z.ram.Write(IF, z.peek(IF)|1<<i)
+	if i == Joypad {
+		z.stopped = false
+	}
+}
+
+// IME returns the state of the interrupt master enable flag
+func (z *SM83) IME() bool {
+	return z.ime
+}
+
+// pendingInterrupts returns the interrupts that are both requested and enabled
+func (z *SM83) pendingInterrupts() byte {
+	return z.peek(IE) & z.peek(IF) & 0x1F
+}
+
+// ei enables interrupts after the instruction following EI has executed
+func (z *SM83) ei() {
+	z.imePending = true
+}
+
+func (z *SM83) di() {
+	z.ime = false
+	z.imePending = false
+}
+
+// interrupt dispatches the highest priority pending interrupt, if any.
+// It returns true when an interrupt handler was called.
+func (z *SM83) interrupt() bool {
+	if !z.ime {
+		return false
+	}
+
+	pending := z.pendingInterrupts()
+	if pending == 0 {
+		return false
+	}
+
+	for i := VBlank; i <= Joypad; i++ {
+		if pending&(1<<i) == 0 {
+			continue
+		}
+
+		for _, o := range z.observers {
+			o.InterruptDispatch(z, i)
+		}
+
+		// two wait states, then PC is pushed and loaded with the vector
+		z.ime = false
+		z.ram.Write(IF, z.peek(IF)&^(1<<i))
+		z.internal()
+		z.internal()
+		// after EI; HALT the HALT bug leaves PC on the byte after HALT, and
+		// the handler returns to the HALT instead
+		if z.haltBug {
+			z.PC--
+			z.haltBug = false
+		}
+		z.push(z.PC)
+		z.PC = 0x40 + uint16(i)*8
+		break
+	}
+
+	return true
+}
@@ -0,0 +1,234 @@
+package cpu
+
+import (
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+func TestRequestInterrupt(t *testing.T) {
+	tbl := []struct {
+		name      string
+		interrupt Interrupt
+		expected  byte
+	}{
+		{"VBlank", VBlank, 0b00001},
+		{"LCD STAT", LCDStat, 0b00010},
+		{"Timer", Timer, 0b00100},
+		{"Serial", Serial, 0b01000},
+		{"Joypad", Joypad, 0b10000},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := NewSM83()
+			z.RequestInterrupt(tc.interrupt)
+			if v := z.ram.Read(IF); v != tc.expected {
+				t.Errorf("expected IF %b, got %b", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestInterruptDispatch(t *testing.T) {
+	tbl := []struct {
+		name       string
+		ie, flags  byte
+		ime        bool
+		expectedPC uint16
+		expectedIF byte
+		cycles     int
+	}{
+		{
+			name:       "VBlank",
+			ie:         0x1F,
+			flags:      0b00001,
+			ime:        true,
+			expectedPC: 0x0040,
+			expectedIF: 0b00000,
+			cycles:     20,
+		},
+		{
+			name:       "LCD STAT",
+			ie:         0x1F,
+			flags:      0b00010,
+			ime:        true,
+			expectedPC: 0x0048,
+			expectedIF: 0b00000,
+			cycles:     20,
+		},
+		{
+			name:       "Timer",
+			ie:         0x1F,
+			flags:      0b00100,
+			ime:        true,
+			expectedPC: 0x0050,
+			expectedIF: 0b00000,
+			cycles:     20,
+		},
+		{
+			name:       "Serial",
+			ie:         0x1F,
+			flags:      0b01000,
+			ime:        true,
+			expectedPC: 0x0058,
+			expectedIF: 0b00000,
+			cycles:     20,
+		},
+		{
+			name:       "Joypad",
+			ie:         0x1F,
+			flags:      0b10000,
+			ime:        true,
+			expectedPC: 0x0060,
+			expectedIF: 0b00000,
+			cycles:     20,
+		},
+		{
+			name:       "Priority",
+			ie:         0x1F,
+			flags:      0b10100,
+			ime:        true,
+			expectedPC: 0x0050,
+			expectedIF: 0b10000,
+			cycles:     20,
+		},
+		{
+			name:       "Priority ignores disabled lines",
+			ie:         0b10000,
+			flags:      0b10100,
+			ime:        true,
+			expectedPC: 0x0060,
+			expectedIF: 0b00100,
+			cycles:     20,
+		},
+		{
+			name:       "IME disabled",
+			ie:         0x1F,
+			flags:      0b00001,
+			ime:        false,
+			expectedPC: 0x0101,
+			expectedIF: 0b00001,
+			cycles:     4,
+		},
+		{
+			name:       "IE disabled",
+			ie:         0x00,
+			flags:      0b00001,
+			ime:        true,
+			expectedPC: 0x0101,
+			expectedIF: 0b00001,
+			cycles:     4,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{SP: 0xFFFE, ram: memory.NewMemory(), ime: tc.ime}
+			z.LoadProgram([]byte{0x00}, 0x0100)
+			z.ram.Write(IE, tc.ie)
+			z.ram.Write(IF, tc.flags)
+
+			err := z.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if z.PC != tc.expectedPC {
+				t.Errorf("expected PC %x, got %x", tc.expectedPC, z.PC)
+			}
+
+			if v := z.ram.Read(IF); v != tc.expectedIF {
+				t.Errorf("expected IF %b, got %b", tc.expectedIF, v)
+			}
+
+			if z.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, z.cycles)
+			}
+
+			if tc.expectedPC != 0x0101 {
+				if z.ime {
+					t.Errorf("expected IME to be disabled during dispatch")
+				}
+				if ret := z.pop(); ret != 0x0100 {
+					t.Errorf("expected return address %x, got %x", 0x0100, ret)
+				}
+			}
+		})
+	}
+}
+
+func TestEI_DI(t *testing.T) {
+	tbl := []struct {
+		name    string
+		program []byte
+		ime     bool
+		ime1    bool
+		ime2    bool
+	}{
+		{
+			name:    "EI is delayed by one instruction",
+			program: []byte{0xFB, 0x00},
+			ime1:    false,
+			ime2:    true,
+		},
+		{
+			name:    "DI is immediate",
+			program: []byte{0xF3, 0x00},
+			ime:     true,
+			ime1:    false,
+			ime2:    false,
+		},
+		{
+			name:    "DI cancels a pending EI",
+			program: []byte{0xFB, 0xF3},
+			ime1:    false,
+			ime2:    false,
+		},
+		{
+			name:    "RETI is immediate",
+			program: []byte{0xD9},
+			ime1:    true,
+			ime2:    true,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{SP: 0xFFFC, ram: memory.NewMemory(), ime: tc.ime}
+			z.LoadProgram(tc.program, 0)
+
+			z.step()
+			if z.IME() != tc.ime1 {
+				t.Errorf("expected IME %v after first step, got %v", tc.ime1, z.IME())
+			}
+
+			z.step()
+			if z.IME() != tc.ime2 {
+				t.Errorf("expected IME %v after second step, got %v", tc.ime2, z.IME())
+			}
+		})
+	}
+}
+
+func TestEIDelaysDispatch(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0xFB, 0x3C, 0x3C}, 0x0100)
+	z.ram.Write(IE, 0x01)
+	z.RequestInterrupt(VBlank)
+
+	// EI, then the instruction after EI runs before the interrupt is serviced
+	z.step()
+	z.step()
+	if z.A != 1 || z.PC != 0x0102 {
+		t.Fatalf("expected A=1 and PC=0102 before dispatch, got A=%x and PC=%x", z.A, z.PC)
+	}
+
+	z.step()
+	if z.PC != 0x0040 {
+		t.Errorf("expected PC %x, got %x", 0x0040, z.PC)
+	}
+	if ret := z.pop(); ret != 0x0102 {
+		t.Errorf("expected return address %x, got %x", 0x0102, ret)
+	}
+}
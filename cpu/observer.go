@@ -0,0 +1,59 @@
+package cpu
+
+import "github.com/danicat/gogoboy/memory"
+
+// Observer receives execution events, for tracers, profilers, debuggers and
+// bots. Embed BaseObserver to implement only the events of interest.
+type Observer interface {
+	// MemoryRead and MemoryWrite are called for every access to memory
+	memory.Observer
+
+	// BeforeInstruction is called before the instruction at z.PC runs
+	BeforeInstruction(z *SM83)
+
+	// AfterInstruction is called after the instruction at pc has run
+	AfterInstruction(z *SM83, pc uint16)
+
+	// InterruptDispatch is called when the CPU starts servicing interrupt i
+	InterruptDispatch(z *SM83, i Interrupt)
+
+	// CycleAdvance is called as the clock advances, with the same M-cycles a
+	// TickFunc would receive
+	CycleAdvance(z *SM83, mcycles int)
+}
+
+// BaseObserver implements Observer ignoring every event
+type BaseObserver struct{}
+
+func (BaseObserver) MemoryRead(addr uint16, val byte)       {}
+func (BaseObserver) MemoryWrite(addr uint16, val byte)      {}
+func (BaseObserver) BeforeInstruction(z *SM83)              {}
+func (BaseObserver) AfterInstruction(z *SM83, pc uint16)    {}
+func (BaseObserver) InterruptDispatch(z *SM83, i Interrupt) {}
+func (BaseObserver) CycleAdvance(z *SM83, mcycles int)      {}
+
+// AddObserver subscribes o to the events of the CPU and its memory. o must be
+// comparable, usually a pointer, so that it can be removed. Observers see
+// every memory access, so the block cache is bypassed while any is attached.
+func (z *SM83) AddObserver(o Observer) {
+	z.observers = append(z.observers, o)
+	z.hooked = true
+	if m, ok := z.ram.(memory.Inspectable); ok {
+		m.AddObserver(o)
+	}
+}
+
+// RemoveObserver unsubscribes o
+func (z *SM83) RemoveObserver(o Observer) {
+	var observers []Observer
+	for _, v := range z.observers {
+		if v != o {
+			observers = append(observers, v)
+		}
+	}
+	z.observers = observers
+	z.hooked = z.tickers != nil || z.observers != nil
+	if m, ok := z.ram.(memory.Inspectable); ok {
+		m.RemoveObserver(o)
+	}
+}
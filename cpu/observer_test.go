@@ -0,0 +1,157 @@
+package cpu
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// recorder logs the events it observes
+type recorder struct {
+	BaseObserver
+	events []string
+}
+
+func (r *recorder) MemoryRead(addr uint16, val byte) {
+	r.events = append(r.events, fmt.Sprintf("read %04X %02X", addr, val))
+}
+
+func (r *recorder) MemoryWrite(addr uint16, val byte) {
+	r.events = append(r.events, fmt.Sprintf("write %04X %02X", addr, val))
+}
+
+func (r *recorder) BeforeInstruction(z *SM83) {
+	r.events = append(r.events, fmt.Sprintf("before %04X", z.PC))
+}
+
+func (r *recorder) AfterInstruction(z *SM83, pc uint16) {
+	r.events = append(r.events, fmt.Sprintf("after %04X", pc))
+}
+
+func (r *recorder) InterruptDispatch(z *SM83, i Interrupt) {
+	r.events = append(r.events, fmt.Sprintf("interrupt %d", i))
+}
+
+func (r *recorder) CycleAdvance(z *SM83, mcycles int) {
+	r.events = append(r.events, fmt.Sprintf("tick %d", mcycles))
+}
+
+func TestObserver(t *testing.T) {
+	tbl := []struct {
+		name     string
+		fast     bool
+		cached   bool
+		expected []string
+	}{
+		{
+			name: "cycle accurate",
+			expected: []string{
+				"before 0000",
+				"tick 1", "read 0000 3E",
+				"tick 1", "read 0001 42",
+				"after 0000",
+				"before 0002",
+				"tick 1", "read 0002 EA",
+				"tick 1", "read 0003 00",
+				"tick 1", "read 0004 C0",
+				"tick 1", "write C000 42",
+				"after 0002",
+				"write FF0F 04",
+				"interrupt 2",
+				"write FF0F 00",
+				"tick 1", "tick 1", "tick 1",
+				"tick 1", "write FFFD 00",
+				"tick 1", "write FFFC 05",
+			},
+		},
+		{
+			name:   "fast mode with cache",
+			fast:   true,
+			cached: true,
+			expected: []string{
+				"before 0000",
+				"read 0000 3E",
+				"read 0001 42",
+				"after 0000",
+				"tick 2",
+				"before 0002",
+				"read 0002 EA",
+				"read 0003 00",
+				"read 0004 C0",
+				"write C000 42",
+				"after 0002",
+				"tick 4",
+				"write FF0F 04",
+				"interrupt 2",
+				"write FF0F 00",
+				"write FFFD 00",
+				"write FFFC 05",
+				"tick 5",
+			},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{SP: 0xFFFE, ime: true, ram: memory.NewMemory()}
+			z.SetFastMode(tc.fast)
+			z.SetBlockCache(tc.cached)
+			z.LoadProgram([]byte{0x3E, 0x42, 0xEA, 0x00, 0xC0}, 0)
+			z.ram.Write(IE, 0xFF)
+
+			r := &recorder{}
+			z.AddObserver(r)
+
+			for i := 0; i < 3; i++ {
+				if i == 2 {
+					z.RequestInterrupt(Timer)
+				}
+				if _, err := z.Step(); err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+			}
+
+			if !reflect.DeepEqual(r.events, tc.expected) {
+				t.Errorf("expected:\n%v\ngot:\n%v", tc.expected, r.events)
+			}
+		})
+	}
+}
+
+func TestRemoveObserver(t *testing.T) {
+	z := &SM83{ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0x3C, 0x3C, 0x3C}, 0)
+
+	r1, r2 := &recorder{}, &recorder{}
+	z.AddObserver(r1)
+	z.AddObserver(r2)
+
+	if err := z.step(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	z.RemoveObserver(r1)
+	if err := z.step(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	z.RemoveObserver(r2)
+	if err := z.step(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	if len(r1.events) != 4 {
+		t.Errorf("expected 4 events, got %v", r1.events)
+	}
+	if len(r2.events) != 8 {
+		t.Errorf("expected 8 events, got %v", r2.events)
+	}
+	if z.observers != nil {
+		t.Errorf("expected no observers, got %v", z.observers)
+	}
+	if z.A != 3 {
+		t.Errorf("expected A=%x, got %x", 3, z.A)
+	}
+}
@@ -1,110 +1,598 @@
 package cpu
 
-var opcodes = map[byte]struct {
+// opcode describes one instruction. For conditional instructions cycles is
+// the cost when the condition is not met. The cycles actually used are
+// counted by exec as it accesses memory, so cycles is only a reference.
+type opcode struct {
 	name   string
 	cycles int
-	exec   func(z *Z80)
-}{
-	0x00: {"NOP", 4, func(z *Z80) {}},
+	exec   func(z *SM83)
+}
+
+// opcodes is indexed by the opcode byte. Entries without exec are not implemented.
+var opcodes = [256]opcode{
+	0x00: {"NOP", 4, func(z *SM83) {}},
 
 	// 8-Bit Loads
-	0x3E: {"LD A, n", 8, func(z *Z80) { z.A = z.fetch() }},
-	0x06: {"LD B, n", 8, func(z *Z80) { z.B = z.fetch() }},
-	0x0E: {"LD C, n", 8, func(z *Z80) { z.C = z.fetch() }},
-	0x16: {"LD D, n", 8, func(z *Z80) { z.D = z.fetch() }},
-	0x1E: {"LD E, n", 8, func(z *Z80) { z.E = z.fetch() }},
-	0x26: {"LD H, n", 8, func(z *Z80) { z.H = z.fetch() }},
-	0x2E: {"LD L, n", 8, func(z *Z80) { z.L = z.fetch() }},
-
-	0x7F: {"LD A, A", 4, func(z *Z80) {}},
-	0x78: {"LD A, B", 4, func(z *Z80) { z.A = z.B }},
-	0x79: {"LD A, C", 4, func(z *Z80) { z.A = z.C }},
-	0x7A: {"LD A, D", 4, func(z *Z80) { z.A = z.D }},
-	0x7B: {"LD A, E", 4, func(z *Z80) { z.A = z.E }},
-	0x7C: {"LD A, H", 4, func(z *Z80) { z.A = z.H }},
-	0x7D: {"LD A, L", 4, func(z *Z80) { z.A = z.L }},
-
-	0x66: {"LD H, (HL)", 8, func(z *Z80) { z.H = z.ram.Read(z.HL()) }},
+	0x06: {"LD B, n", 8, func(z *SM83) { z.B = z.fetch() }},
+	0x0E: {"LD C, n", 8, func(z *SM83) { z.C = z.fetch() }},
+	0x16: {"LD D, n", 8, func(z *SM83) { z.D = z.fetch() }},
+	0x1E: {"LD E, n", 8, func(z *SM83) { z.E = z.fetch() }},
+	0x26: {"LD H, n", 8, func(z *SM83) { z.H = z.fetch() }},
+	0x2E: {"LD L, n", 8, func(z *SM83) { z.L = z.fetch() }},
+	0x36: {"LD (HL), n", 12, func(z *SM83) { z.write(z.HL(), z.fetch()) }},
+	0x3E: {"LD A, n", 8, func(z *SM83) { z.A = z.fetch() }},
+
+	0x40: {"LD B, B", 4, func(z *SM83) {}},
+	0x41: {"LD B, C", 4, func(z *SM83) { z.B = z.C }},
+	0x42: {"LD B, D", 4, func(z *SM83) { z.B = z.D }},
+	0x43: {"LD B, E", 4, func(z *SM83) { z.B = z.E }},
+	0x44: {"LD B, H", 4, func(z *SM83) { z.B = z.H }},
+	0x45: {"LD B, L", 4, func(z *SM83) { z.B = z.L }},
+	0x46: {"LD B, (HL)", 8, func(z *SM83) { z.B = z.read(z.HL()) }},
+	0x47: {"LD B, A", 4, func(z *SM83) { z.B = z.A }},
+
+	0x48: {"LD C, B", 4, func(z *SM83) { z.C = z.B }},
+	0x49: {"LD C, C", 4, func(z *SM83) {}},
+	0x4A: {"LD C, D", 4, func(z *SM83) { z.C = z.D }},
+	0x4B: {"LD C, E", 4, func(z *SM83) { z.C = z.E }},
+	0x4C: {"LD C, H", 4, func(z *SM83) { z.C = z.H }},
+	0x4D: {"LD C, L", 4, func(z *SM83) { z.C = z.L }},
+	0x4E: {"LD C, (HL)", 8, func(z *SM83) { z.C = z.read(z.HL()) }},
+	0x4F: {"LD C, A", 4, func(z *SM83) { z.C = z.A }},
+
+	0x50: {"LD D, B", 4, func(z *SM83) { z.D = z.B }},
+	0x51: {"LD D, C", 4, func(z *SM83) { z.D = z.C }},
+	0x52: {"LD D, D", 4, func(z *SM83) {}},
+	0x53: {"LD D, E", 4, func(z *SM83) { z.D = z.E }},
+	0x54: {"LD D, H", 4, func(z *SM83) { z.D = z.H }},
+	0x55: {"LD D, L", 4, func(z *SM83) { z.D = z.L }},
+	0x56: {"LD D, (HL)", 8, func(z *SM83) { z.D = z.read(z.HL()) }},
+	0x57: {"LD D, A", 4, func(z *SM83) { z.D = z.A }},
+
+	0x58: {"LD E, B", 4, func(z *SM83) { z.E = z.B }},
+	0x59: {"LD E, C", 4, func(z *SM83) { z.E = z.C }},
+	0x5A: {"LD E, D", 4, func(z *SM83) { z.E = z.D }},
+	0x5B: {"LD E, E", 4, func(z *SM83) {}},
+	0x5C: {"LD E, H", 4, func(z *SM83) { z.E = z.H }},
+	0x5D: {"LD E, L", 4, func(z *SM83) { z.E = z.L }},
+	0x5E: {"LD E, (HL)", 8, func(z *SM83) { z.E = z.read(z.HL()) }},
+	0x5F: {"LD E, A", 4, func(z *SM83) { z.E = z.A }},
+
+	0x60: {"LD H, B", 4, func(z *SM83) { z.H = z.B }},
+	0x61: {"LD H, C", 4, func(z *SM83) { z.H = z.C }},
+	0x62: {"LD H, D", 4, func(z *SM83) { z.H = z.D }},
+	0x63: {"LD H, E", 4, func(z *SM83) { z.H = z.E }},
+	0x64: {"LD H, H", 4, func(z *SM83) {}},
+	0x65: {"LD H, L", 4, func(z *SM83) { z.H = z.L }},
+	0x66: {"LD H, (HL)", 8, func(z *SM83) { z.H = z.read(z.HL()) }},
+	0x67: {"LD H, A", 4, func(z *SM83) { z.H = z.A }},
+
+	0x68: {"LD L, B", 4, func(z *SM83) { z.L = z.B }},
+	0x69: {"LD L, C", 4, func(z *SM83) { z.L = z.C }},
+	0x6A: {"LD L, D", 4, func(z *SM83) { z.L = z.D }},
+	0x6B: {"LD L, E", 4, func(z *SM83) { z.L = z.E }},
+	0x6C: {"LD L, H", 4, func(z *SM83) { z.L = z.H }},
+	0x6D: {"LD L, L", 4, func(z *SM83) {}},
+	0x6E: {"LD L, (HL)", 8, func(z *SM83) { z.L = z.read(z.HL()) }},
+	0x6F: {"LD L, A", 4, func(z *SM83) { z.L = z.A }},
+
+	0x70: {"LD (HL), B", 8, func(z *SM83) { z.write(z.HL(), z.B) }},
+	0x71: {"LD (HL), C", 8, func(z *SM83) { z.write(z.HL(), z.C) }},
+	0x72: {"LD (HL), D", 8, func(z *SM83) { z.write(z.HL(), z.D) }},
+	0x73: {"LD (HL), E", 8, func(z *SM83) { z.write(z.HL(), z.E) }},
+	0x74: {"LD (HL), H", 8, func(z *SM83) { z.write(z.HL(), z.H) }},
+	0x75: {"LD (HL), L", 8, func(z *SM83) { z.write(z.HL(), z.L) }},
+	0x77: {"LD (HL), A", 8, func(z *SM83) { z.write(z.HL(), z.A) }},
+
+	0x78: {"LD A, B", 4, func(z *SM83) { z.A = z.B }},
+	0x79: {"LD A, C", 4, func(z *SM83) { z.A = z.C }},
+	0x7A: {"LD A, D", 4, func(z *SM83) { z.A = z.D }},
+	0x7B: {"LD A, E", 4, func(z *SM83) { z.A = z.E }},
+	0x7C: {"LD A, H", 4, func(z *SM83) { z.A = z.H }},
+	0x7D: {"LD A, L", 4, func(z *SM83) { z.A = z.L }},
+	0x7E: {"LD A, (HL)", 8, func(z *SM83) { z.A = z.read(z.HL()) }},
+	0x7F: {"LD A, A", 4, func(z *SM83) {}},
+
+	0x0A: {"LD A, (BC)", 8, func(z *SM83) { z.A = z.read(z.BC()) }},
+	0x1A: {"LD A, (DE)", 8, func(z *SM83) { z.A = z.read(z.DE()) }},
+	0xFA: {"LD A, (nn)", 16, func(z *SM83) { z.A = z.read(z.fetch16()) }},
+
+	0x02: {"LD (BC), A", 8, func(z *SM83) { z.write(z.BC(), z.A) }},
+	0x12: {"LD (DE), A", 8, func(z *SM83) { z.write(z.DE(), z.A) }},
+	0xEA: {"LD (nn), A", 16, func(z *SM83) { z.write(z.fetch16(), z.A) }},
+
+	0xE0: {"LDH (n), A", 12, func(z *SM83) { z.write(0xFF00+uint16(z.fetch()), z.A) }},
+	0xF0: {"LDH A, (n)", 12, func(z *SM83) { z.A = z.read(0xFF00 + uint16(z.fetch())) }},
+	0xE2: {"LD (C), A", 8, func(z *SM83) { z.write(0xFF00+uint16(z.C), z.A) }},
+	0xF2: {"LD A, (C)", 8, func(z *SM83) { z.A = z.read(0xFF00 + uint16(z.C)) }},
+
+	0x22: {"LDI (HL), A", 8, func(z *SM83) { z.write(z.HL(), z.A); z.inc(&z.H, &z.L) }},
+	0x2A: {"LDI A, (HL)", 8, func(z *SM83) { z.A = z.read(z.HL()); z.inc(&z.H, &z.L) }},
+	0x32: {"LDD (HL), A", 8, func(z *SM83) { z.write(z.HL(), z.A); z.dec(&z.H, &z.L) }},
+	0x3A: {"LDD A, (HL)", 8, func(z *SM83) { z.A = z.read(z.HL()); z.dec(&z.H, &z.L) }},
 
 	// 16-Bit Loads
-	0x01: {"LD BC, nn", 12, func(z *Z80) { z.B = z.fetch(); z.C = z.fetch() }},
-	0x11: {"LD DE, nn", 12, func(z *Z80) { z.D = z.fetch(); z.E = z.fetch() }},
-	0x21: {"LD HL, nn", 12, func(z *Z80) { z.H = z.fetch(); z.L = z.fetch() }},
-	0x31: {"LD SP, nn", 12, func(z *Z80) { hi := z.fetch(); lo := z.fetch(); z.SP = pair(hi, lo) }},
-
-	0xF9: {"LD SP, HL", 8, func(z *Z80) { z.SP = pair(z.H, z.L) }},
-
-	0xF8: {"LD SP, HL", 12, func(z *Z80) {
-		n := uint(z.fetch())
-		SP := uint(z.SP)
-		res := SP + n
-
-		z.ResetZFlag()
-		z.ResetNFlag()
-
-		if res > 0xFFFF {
-			z.SetCFlag()
-		} else {
-			z.ResetCFlag()
-		}
-
-		if SP&0x00FF+n > 0xFF {
-			z.SetHFlag()
-		} else {
-			z.ResetHFlag()
-		}
-
-		z.H, z.L = split(uint16(res))
-	}},
-
-	0x08: {"LD (nn), SP", 20, func(z *Z80) {
-		hi := z.fetch()
-		lo := z.fetch()
-		z.ram.Write16(pair(hi, lo), z.SP)
-	}},
+	0x01: {"LD BC, nn", 12, func(z *SM83) { z.SetBC(z.fetch16()) }},
+	0x11: {"LD DE, nn", 12, func(z *SM83) { z.SetDE(z.fetch16()) }},
+	0x21: {"LD HL, nn", 12, func(z *SM83) { z.SetHL(z.fetch16()) }},
+	0x31: {"LD SP, nn", 12, func(z *SM83) { z.SP = z.fetch16() }},
+
+	0xF9: {"LD SP, HL", 8, func(z *SM83) { z.SP = z.HL(); z.internal() }},
+
+	0xF8: {"LD HL, SP+e", 12, func(z *SM83) { z.SetHL(z.addSP(z.fetch())); z.internal() }},
+
+	0x08: {"LD (nn), SP", 20, func(z *SM83) { z.write16(z.fetch16(), z.SP) }},
 
 	// 8-Bit ALU
-	0x87: {"ADD A, A", 4, func(z *Z80) { z.A = z.add8(z.A, z.A, false) }},
-	0x80: {"ADD A, B", 4, func(z *Z80) { z.A = z.add8(z.A, z.B, false) }},
-	0x81: {"ADD A, C", 4, func(z *Z80) { z.A = z.add8(z.A, z.C, false) }},
-	0x82: {"ADD A, D", 4, func(z *Z80) { z.A = z.add8(z.A, z.D, false) }},
-	0x83: {"ADD A, E", 4, func(z *Z80) { z.A = z.add8(z.A, z.E, false) }},
-	0x84: {"ADD A, H", 4, func(z *Z80) { z.A = z.add8(z.A, z.H, false) }},
-	0x85: {"ADD A, L", 4, func(z *Z80) { z.A = z.add8(z.A, z.L, false) }},
-	0xC6: {"ADC A, #", 8, func(z *Z80) { z.A = z.add8(z.A, z.fetch(), false) }},
-
-	0x8F: {"ADC A, A", 4, func(z *Z80) { z.A = z.add8(z.A, z.A, z.CFlag()) }},
-	0x88: {"ADC A, B", 4, func(z *Z80) { z.A = z.add8(z.A, z.B, z.CFlag()) }},
-	0x89: {"ADC A, C", 4, func(z *Z80) { z.A = z.add8(z.A, z.C, z.CFlag()) }},
-	0x8A: {"ADC A, D", 4, func(z *Z80) { z.A = z.add8(z.A, z.D, z.CFlag()) }},
-	0x8B: {"ADC A, E", 4, func(z *Z80) { z.A = z.add8(z.A, z.E, z.CFlag()) }},
-	0x8C: {"ADC A, H", 4, func(z *Z80) { z.A = z.add8(z.A, z.H, z.CFlag()) }},
-	0x8D: {"ADC A, L", 4, func(z *Z80) { z.A = z.add8(z.A, z.L, z.CFlag()) }},
-	0xCE: {"ADC A, #", 8, func(z *Z80) { z.A = z.add8(z.A, z.fetch(), z.CFlag()) }},
-
-	0x03: {"INC BC", 8, func(z *Z80) { z.inc(&z.B, &z.C) }},
-	0x13: {"INC DE", 8, func(z *Z80) { z.inc(&z.D, &z.E) }},
-	0x23: {"INC HL", 8, func(z *Z80) { z.inc(&z.H, &z.L) }},
-	0x33: {"INC SP", 8, func(z *Z80) { z.SP++ }},
-
-	0x0B: {"DEC BC", 8, func(z *Z80) { z.dec(&z.B, &z.C) }},
-	0x1B: {"DEC DE", 8, func(z *Z80) { z.dec(&z.D, &z.E) }},
-	0x2B: {"DEC HL", 8, func(z *Z80) { z.dec(&z.H, &z.L) }},
-	0x3B: {"DEC SP", 8, func(z *Z80) { z.SP-- }},
+	0x87: {"ADD A, A", 4, func(z *SM83) { z.A = z.add8(z.A, z.A, false) }},
+	0x80: {"ADD A, B", 4, func(z *SM83) { z.A = z.add8(z.A, z.B, false) }},
+	0x81: {"ADD A, C", 4, func(z *SM83) { z.A = z.add8(z.A, z.C, false) }},
+	0x82: {"ADD A, D", 4, func(z *SM83) { z.A = z.add8(z.A, z.D, false) }},
+	0x83: {"ADD A, E", 4, func(z *SM83) { z.A = z.add8(z.A, z.E, false) }},
+	0x84: {"ADD A, H", 4, func(z *SM83) { z.A = z.add8(z.A, z.H, false) }},
+	0x85: {"ADD A, L", 4, func(z *SM83) { z.A = z.add8(z.A, z.L, false) }},
+	0x86: {"ADD A, (HL)", 8, func(z *SM83) { z.A = z.add8(z.A, z.read(z.HL()), false) }},
+	0xC6: {"ADD A, n", 8, func(z *SM83) { z.A = z.add8(z.A, z.fetch(), false) }},
+
+	0x8F: {"ADC A, A", 4, func(z *SM83) { z.A = z.add8(z.A, z.A, z.CFlag()) }},
+	0x88: {"ADC A, B", 4, func(z *SM83) { z.A = z.add8(z.A, z.B, z.CFlag()) }},
+	0x89: {"ADC A, C", 4, func(z *SM83) { z.A = z.add8(z.A, z.C, z.CFlag()) }},
+	0x8A: {"ADC A, D", 4, func(z *SM83) { z.A = z.add8(z.A, z.D, z.CFlag()) }},
+	0x8B: {"ADC A, E", 4, func(z *SM83) { z.A = z.add8(z.A, z.E, z.CFlag()) }},
+	0x8C: {"ADC A, H", 4, func(z *SM83) { z.A = z.add8(z.A, z.H, z.CFlag()) }},
+	0x8D: {"ADC A, L", 4, func(z *SM83) { z.A = z.add8(z.A, z.L, z.CFlag()) }},
+	0x8E: {"ADC A, (HL)", 8, func(z *SM83) { z.A = z.add8(z.A, z.read(z.HL()), z.CFlag()) }},
+	0xCE: {"ADC A, n", 8, func(z *SM83) { z.A = z.add8(z.A, z.fetch(), z.CFlag()) }},
+
+	0x97: {"SUB A, A", 4, func(z *SM83) { z.A = z.sub8(z.A, z.A, false) }},
+	0x90: {"SUB A, B", 4, func(z *SM83) { z.A = z.sub8(z.A, z.B, false) }},
+	0x91: {"SUB A, C", 4, func(z *SM83) { z.A = z.sub8(z.A, z.C, false) }},
+	0x92: {"SUB A, D", 4, func(z *SM83) { z.A = z.sub8(z.A, z.D, false) }},
+	0x93: {"SUB A, E", 4, func(z *SM83) { z.A = z.sub8(z.A, z.E, false) }},
+	0x94: {"SUB A, H", 4, func(z *SM83) { z.A = z.sub8(z.A, z.H, false) }},
+	0x95: {"SUB A, L", 4, func(z *SM83) { z.A = z.sub8(z.A, z.L, false) }},
+	0x96: {"SUB A, (HL)", 8, func(z *SM83) { z.A = z.sub8(z.A, z.read(z.HL()), false) }},
+	0xD6: {"SUB A, n", 8, func(z *SM83) { z.A = z.sub8(z.A, z.fetch(), false) }},
+
+	0x9F: {"SBC A, A", 4, func(z *SM83) { z.A = z.sub8(z.A, z.A, z.CFlag()) }},
+	0x98: {"SBC A, B", 4, func(z *SM83) { z.A = z.sub8(z.A, z.B, z.CFlag()) }},
+	0x99: {"SBC A, C", 4, func(z *SM83) { z.A = z.sub8(z.A, z.C, z.CFlag()) }},
+	0x9A: {"SBC A, D", 4, func(z *SM83) { z.A = z.sub8(z.A, z.D, z.CFlag()) }},
+	0x9B: {"SBC A, E", 4, func(z *SM83) { z.A = z.sub8(z.A, z.E, z.CFlag()) }},
+	0x9C: {"SBC A, H", 4, func(z *SM83) { z.A = z.sub8(z.A, z.H, z.CFlag()) }},
+	0x9D: {"SBC A, L", 4, func(z *SM83) { z.A = z.sub8(z.A, z.L, z.CFlag()) }},
+	0x9E: {"SBC A, (HL)", 8, func(z *SM83) { z.A = z.sub8(z.A, z.read(z.HL()), z.CFlag()) }},
+	0xDE: {"SBC A, n", 8, func(z *SM83) { z.A = z.sub8(z.A, z.fetch(), z.CFlag()) }},
+
+	0xA7: {"AND A, A", 4, func(z *SM83) { z.A = z.and8(z.A, z.A) }},
+	0xA0: {"AND A, B", 4, func(z *SM83) { z.A = z.and8(z.A, z.B) }},
+	0xA1: {"AND A, C", 4, func(z *SM83) { z.A = z.and8(z.A, z.C) }},
+	0xA2: {"AND A, D", 4, func(z *SM83) { z.A = z.and8(z.A, z.D) }},
+	0xA3: {"AND A, E", 4, func(z *SM83) { z.A = z.and8(z.A, z.E) }},
+	0xA4: {"AND A, H", 4, func(z *SM83) { z.A = z.and8(z.A, z.H) }},
+	0xA5: {"AND A, L", 4, func(z *SM83) { z.A = z.and8(z.A, z.L) }},
+	0xA6: {"AND A, (HL)", 8, func(z *SM83) { z.A = z.and8(z.A, z.read(z.HL())) }},
+	0xE6: {"AND A, n", 8, func(z *SM83) { z.A = z.and8(z.A, z.fetch()) }},
+
+	0xAF: {"XOR A, A", 4, func(z *SM83) { z.A = z.xor8(z.A, z.A) }},
+	0xA8: {"XOR A, B", 4, func(z *SM83) { z.A = z.xor8(z.A, z.B) }},
+	0xA9: {"XOR A, C", 4, func(z *SM83) { z.A = z.xor8(z.A, z.C) }},
+	0xAA: {"XOR A, D", 4, func(z *SM83) { z.A = z.xor8(z.A, z.D) }},
+	0xAB: {"XOR A, E", 4, func(z *SM83) { z.A = z.xor8(z.A, z.E) }},
+	0xAC: {"XOR A, H", 4, func(z *SM83) { z.A = z.xor8(z.A, z.H) }},
+	0xAD: {"XOR A, L", 4, func(z *SM83) { z.A = z.xor8(z.A, z.L) }},
+	0xAE: {"XOR A, (HL)", 8, func(z *SM83) { z.A = z.xor8(z.A, z.read(z.HL())) }},
+	0xEE: {"XOR A, n", 8, func(z *SM83) { z.A = z.xor8(z.A, z.fetch()) }},
+
+	0xB7: {"OR A, A", 4, func(z *SM83) { z.A = z.or8(z.A, z.A) }},
+	0xB0: {"OR A, B", 4, func(z *SM83) { z.A = z.or8(z.A, z.B) }},
+	0xB1: {"OR A, C", 4, func(z *SM83) { z.A = z.or8(z.A, z.C) }},
+	0xB2: {"OR A, D", 4, func(z *SM83) { z.A = z.or8(z.A, z.D) }},
+	0xB3: {"OR A, E", 4, func(z *SM83) { z.A = z.or8(z.A, z.E) }},
+	0xB4: {"OR A, H", 4, func(z *SM83) { z.A = z.or8(z.A, z.H) }},
+	0xB5: {"OR A, L", 4, func(z *SM83) { z.A = z.or8(z.A, z.L) }},
+	0xB6: {"OR A, (HL)", 8, func(z *SM83) { z.A = z.or8(z.A, z.read(z.HL())) }},
+	0xF6: {"OR A, n", 8, func(z *SM83) { z.A = z.or8(z.A, z.fetch()) }},
+
+	0xBF: {"CP A, A", 4, func(z *SM83) { z.cp(z.A) }},
+	0xB8: {"CP A, B", 4, func(z *SM83) { z.cp(z.B) }},
+	0xB9: {"CP A, C", 4, func(z *SM83) { z.cp(z.C) }},
+	0xBA: {"CP A, D", 4, func(z *SM83) { z.cp(z.D) }},
+	0xBB: {"CP A, E", 4, func(z *SM83) { z.cp(z.E) }},
+	0xBC: {"CP A, H", 4, func(z *SM83) { z.cp(z.H) }},
+	0xBD: {"CP A, L", 4, func(z *SM83) { z.cp(z.L) }},
+	0xBE: {"CP A, (HL)", 8, func(z *SM83) { z.cp(z.read(z.HL())) }},
+	0xFE: {"CP A, n", 8, func(z *SM83) { z.cp(z.fetch()) }},
+
+	0x3C: {"INC A", 4, func(z *SM83) { z.A = z.inc8(z.A) }},
+	0x04: {"INC B", 4, func(z *SM83) { z.B = z.inc8(z.B) }},
+	0x0C: {"INC C", 4, func(z *SM83) { z.C = z.inc8(z.C) }},
+	0x14: {"INC D", 4, func(z *SM83) { z.D = z.inc8(z.D) }},
+	0x1C: {"INC E", 4, func(z *SM83) { z.E = z.inc8(z.E) }},
+	0x24: {"INC H", 4, func(z *SM83) { z.H = z.inc8(z.H) }},
+	0x2C: {"INC L", 4, func(z *SM83) { z.L = z.inc8(z.L) }},
+	0x34: {"INC (HL)", 12, func(z *SM83) { z.write(z.HL(), z.inc8(z.read(z.HL()))) }},
+
+	0x3D: {"DEC A", 4, func(z *SM83) { z.A = z.dec8(z.A) }},
+	0x05: {"DEC B", 4, func(z *SM83) { z.B = z.dec8(z.B) }},
+	0x0D: {"DEC C", 4, func(z *SM83) { z.C = z.dec8(z.C) }},
+	0x15: {"DEC D", 4, func(z *SM83) { z.D = z.dec8(z.D) }},
+	0x1D: {"DEC E", 4, func(z *SM83) { z.E = z.dec8(z.E) }},
+	0x25: {"DEC H", 4, func(z *SM83) { z.H = z.dec8(z.H) }},
+	0x2D: {"DEC L", 4, func(z *SM83) { z.L = z.dec8(z.L) }},
+	0x35: {"DEC (HL)", 12, func(z *SM83) { z.write(z.HL(), z.dec8(z.read(z.HL()))) }},
+
+	0x27: {"DAA", 4, func(z *SM83) { z.daa() }},
+	0x2F: {"CPL", 4, func(z *SM83) { z.cpl() }},
+	0x37: {"SCF", 4, func(z *SM83) { z.scf() }},
+	0x3F: {"CCF", 4, func(z *SM83) { z.ccf() }},
+
+	// 16-Bit ALU
+	0x03: {"INC BC", 8, func(z *SM83) { z.inc(&z.B, &z.C); z.internal() }},
+	0x13: {"INC DE", 8, func(z *SM83) { z.inc(&z.D, &z.E); z.internal() }},
+	0x23: {"INC HL", 8, func(z *SM83) { z.inc(&z.H, &z.L); z.internal() }},
+	0x33: {"INC SP", 8, func(z *SM83) { z.SP++; z.internal() }},
+
+	0x09: {"ADD HL, BC", 8, func(z *SM83) { z.SetHL(z.add16(z.HL(), z.BC())) }},
+	0x19: {"ADD HL, DE", 8, func(z *SM83) { z.SetHL(z.add16(z.HL(), z.DE())) }},
+	0x29: {"ADD HL, HL", 8, func(z *SM83) { z.SetHL(z.add16(z.HL(), z.HL())) }},
+	0x39: {"ADD HL, SP", 8, func(z *SM83) { z.SetHL(z.add16(z.HL(), z.SP)) }},
+	0xE8: {"ADD SP, e", 16, func(z *SM83) { z.SP = z.addSP(z.fetch()); z.internal(); z.internal() }},
+
+	0x0B: {"DEC BC", 8, func(z *SM83) { z.dec(&z.B, &z.C); z.internal() }},
+	0x1B: {"DEC DE", 8, func(z *SM83) { z.dec(&z.D, &z.E); z.internal() }},
+	0x2B: {"DEC HL", 8, func(z *SM83) { z.dec(&z.H, &z.L); z.internal() }},
+	0x3B: {"DEC SP", 8, func(z *SM83) { z.SP--; z.internal() }},
+
+	// Interrupts & Power
+	0x76: {"HALT", 4, func(z *SM83) { z.halt() }},
+	0x10: {"STOP", 4, func(z *SM83) { z.stop() }},
+	0xF3: {"DI", 4, func(z *SM83) { z.di() }},
+	0xFB: {"EI", 4, func(z *SM83) { z.ei() }},
+
+	// Rotates & Shifts
+	0x07: {"RLCA", 4, func(z *SM83) { z.rlca() }},
+	0x0F: {"RRCA", 4, func(z *SM83) { z.rrca() }},
+	0x17: {"RLA", 4, func(z *SM83) { z.rla() }},
+	0x1F: {"RRA", 4, func(z *SM83) { z.rra() }},
 
 	// Flow control
-	0xCC: {"CALL Z, nn", 12, func(z *Z80) { z.call(z.ZFlag()) }},
-	0xC4: {"CALL NZ, nn", 12, func(z *Z80) { z.call(!z.ZFlag()) }},
-	0xDC: {"CALL C, nn", 12, func(z *Z80) { z.call(z.CFlag()) }},
-	0xD4: {"CALL NC, nn", 12, func(z *Z80) { z.call(!z.CFlag()) }},
-
-	0xF5: {"PUSH AF", 16, func(z *Z80) { z.push(z.AF()) }},
-	0xC5: {"PUSH BC", 16, func(z *Z80) { z.push(z.BC()) }},
-	0xD5: {"PUSH DE", 16, func(z *Z80) { z.push(z.DE()) }},
-	0xE5: {"PUSH HL", 16, func(z *Z80) { z.push(z.HL()) }},
-
-	0xF1: {"POP AF", 16, func(z *Z80) { z.SetAF(z.pop()) }},
-	0xC1: {"POP BC", 16, func(z *Z80) { z.SetBC(z.pop()) }},
-	0xD1: {"POP DE", 16, func(z *Z80) { z.SetDE(z.pop()) }},
-	0xE1: {"POP HL", 16, func(z *Z80) { z.SetHL(z.pop()) }},
+	0xC3: {"JP nn", 16, func(z *SM83) { z.jump() }},
+	0xC2: {"JP NZ, nn", 12, func(z *SM83) { z.jumpIf(!z.ZFlag()) }},
+	0xCA: {"JP Z, nn", 12, func(z *SM83) { z.jumpIf(z.ZFlag()) }},
+	0xD2: {"JP NC, nn", 12, func(z *SM83) { z.jumpIf(!z.CFlag()) }},
+	0xDA: {"JP C, nn", 12, func(z *SM83) { z.jumpIf(z.CFlag()) }},
+	0xE9: {"JP (HL)", 4, func(z *SM83) { z.PC = z.HL() }},
+
+	0x18: {"JR e", 12, func(z *SM83) { z.jumpRelative() }},
+	0x20: {"JR NZ, e", 8, func(z *SM83) { z.jumpRelativeIf(!z.ZFlag()) }},
+	0x28: {"JR Z, e", 8, func(z *SM83) { z.jumpRelativeIf(z.ZFlag()) }},
+	0x30: {"JR NC, e", 8, func(z *SM83) { z.jumpRelativeIf(!z.CFlag()) }},
+	0x38: {"JR C, e", 8, func(z *SM83) { z.jumpRelativeIf(z.CFlag()) }},
+
+	0xCD: {"CALL nn", 24, func(z *SM83) { z.call() }},
+	0xCC: {"CALL Z, nn", 12, func(z *SM83) { z.callIf(z.ZFlag()) }},
+	0xC4: {"CALL NZ, nn", 12, func(z *SM83) { z.callIf(!z.ZFlag()) }},
+	0xDC: {"CALL C, nn", 12, func(z *SM83) { z.callIf(z.CFlag()) }},
+	0xD4: {"CALL NC, nn", 12, func(z *SM83) { z.callIf(!z.CFlag()) }},
+
+	0xC9: {"RET", 16, func(z *SM83) { z.ret() }},
+	0xC0: {"RET NZ", 8, func(z *SM83) { z.retIf(!z.ZFlag()) }},
+	0xC8: {"RET Z", 8, func(z *SM83) { z.retIf(z.ZFlag()) }},
+	0xD0: {"RET NC", 8, func(z *SM83) { z.retIf(!z.CFlag()) }},
+	0xD8: {"RET C", 8, func(z *SM83) { z.retIf(z.CFlag()) }},
+	0xD9: {"RETI", 16, func(z *SM83) { z.reti() }},
+
+	0xC7: {"RST 00H", 16, func(z *SM83) { z.rst(0x00) }},
+	0xCF: {"RST 08H", 16, func(z *SM83) { z.rst(0x08) }},
+	0xD7: {"RST 10H", 16, func(z *SM83) { z.rst(0x10) }},
+	0xDF: {"RST 18H", 16, func(z *SM83) { z.rst(0x18) }},
+	0xE7: {"RST 20H", 16, func(z *SM83) { z.rst(0x20) }},
+	0xEF: {"RST 28H", 16, func(z *SM83) { z.rst(0x28) }},
+	0xF7: {"RST 30H", 16, func(z *SM83) { z.rst(0x30) }},
+	0xFF: {"RST 38H", 16, func(z *SM83) { z.rst(0x38) }},
+
+	0xF5: {"PUSH AF", 16, func(z *SM83) { z.push(z.AF()) }},
+	0xC5: {"PUSH BC", 16, func(z *SM83) { z.push(z.BC()) }},
+	0xD5: {"PUSH DE", 16, func(z *SM83) { z.push(z.DE()) }},
+	0xE5: {"PUSH HL", 16, func(z *SM83) { z.push(z.HL()) }},
+
+	0xF1: {"POP AF", 12, func(z *SM83) { z.SetAF(z.pop()) }},
+	0xC1: {"POP BC", 12, func(z *SM83) { z.SetBC(z.pop()) }},
+	0xD1: {"POP DE", 12, func(z *SM83) { z.SetDE(z.pop()) }},
+	0xE1: {"POP HL", 12, func(z *SM83) { z.SetHL(z.pop()) }},
+}
+
+// cbOpcodes holds the instructions prefixed by 0xCB. Cycles include the prefix fetch.
+var cbOpcodes = [256]opcode{
+	// Rotates & Shifts
+	0x00: {"RLC B", 8, func(z *SM83) { z.B = z.rlc(z.B) }},
+	0x01: {"RLC C", 8, func(z *SM83) { z.C = z.rlc(z.C) }},
+	0x02: {"RLC D", 8, func(z *SM83) { z.D = z.rlc(z.D) }},
+	0x03: {"RLC E", 8, func(z *SM83) { z.E = z.rlc(z.E) }},
+	0x04: {"RLC H", 8, func(z *SM83) { z.H = z.rlc(z.H) }},
+	0x05: {"RLC L", 8, func(z *SM83) { z.L = z.rlc(z.L) }},
+	0x06: {"RLC (HL)", 16, func(z *SM83) { z.write(z.HL(), z.rlc(z.read(z.HL()))) }},
+	0x07: {"RLC A", 8, func(z *SM83) { z.A = z.rlc(z.A) }},
+
+	0x08: {"RRC B", 8, func(z *SM83) { z.B = z.rrc(z.B) }},
+	0x09: {"RRC C", 8, func(z *SM83) { z.C = z.rrc(z.C) }},
+	0x0A: {"RRC D", 8, func(z *SM83) { z.D = z.rrc(z.D) }},
+	0x0B: {"RRC E", 8, func(z *SM83) { z.E = z.rrc(z.E) }},
+	0x0C: {"RRC H", 8, func(z *SM83) { z.H = z.rrc(z.H) }},
+	0x0D: {"RRC L", 8, func(z *SM83) { z.L = z.rrc(z.L) }},
+	0x0E: {"RRC (HL)", 16, func(z *SM83) { z.write(z.HL(), z.rrc(z.read(z.HL()))) }},
+	0x0F: {"RRC A", 8, func(z *SM83) { z.A = z.rrc(z.A) }},
+
+	0x10: {"RL B", 8, func(z *SM83) { z.B = z.rl(z.B) }},
+	0x11: {"RL C", 8, func(z *SM83) { z.C = z.rl(z.C) }},
+	0x12: {"RL D", 8, func(z *SM83) { z.D = z.rl(z.D) }},
+	0x13: {"RL E", 8, func(z *SM83) { z.E = z.rl(z.E) }},
+	0x14: {"RL H", 8, func(z *SM83) { z.H = z.rl(z.H) }},
+	0x15: {"RL L", 8, func(z *SM83) { z.L = z.rl(z.L) }},
+	0x16: {"RL (HL)", 16, func(z *SM83) { z.write(z.HL(), z.rl(z.read(z.HL()))) }},
+	0x17: {"RL A", 8, func(z *SM83) { z.A = z.rl(z.A) }},
+
+	0x18: {"RR B", 8, func(z *SM83) { z.B = z.rr(z.B) }},
+	0x19: {"RR C", 8, func(z *SM83) { z.C = z.rr(z.C) }},
+	0x1A: {"RR D", 8, func(z *SM83) { z.D = z.rr(z.D) }},
+	0x1B: {"RR E", 8, func(z *SM83) { z.E = z.rr(z.E) }},
+	0x1C: {"RR H", 8, func(z *SM83) { z.H = z.rr(z.H) }},
+	0x1D: {"RR L", 8, func(z *SM83) { z.L = z.rr(z.L) }},
+	0x1E: {"RR (HL)", 16, func(z *SM83) { z.write(z.HL(), z.rr(z.read(z.HL()))) }},
+	0x1F: {"RR A", 8, func(z *SM83) { z.A = z.rr(z.A) }},
+
+	0x20: {"SLA B", 8, func(z *SM83) { z.B = z.sla(z.B) }},
+	0x21: {"SLA C", 8, func(z *SM83) { z.C = z.sla(z.C) }},
+	0x22: {"SLA D", 8, func(z *SM83) { z.D = z.sla(z.D) }},
+	0x23: {"SLA E", 8, func(z *SM83) { z.E = z.sla(z.E) }},
+	0x24: {"SLA H", 8, func(z *SM83) { z.H = z.sla(z.H) }},
+	0x25: {"SLA L", 8, func(z *SM83) { z.L = z.sla(z.L) }},
+	0x26: {"SLA (HL)", 16, func(z *SM83) { z.write(z.HL(), z.sla(z.read(z.HL()))) }},
+	0x27: {"SLA A", 8, func(z *SM83) { z.A = z.sla(z.A) }},
+
+	0x28: {"SRA B", 8, func(z *SM83) { z.B = z.sra(z.B) }},
+	0x29: {"SRA C", 8, func(z *SM83) { z.C = z.sra(z.C) }},
+	0x2A: {"SRA D", 8, func(z *SM83) { z.D = z.sra(z.D) }},
+	0x2B: {"SRA E", 8, func(z *SM83) { z.E = z.sra(z.E) }},
+	0x2C: {"SRA H", 8, func(z *SM83) { z.H = z.sra(z.H) }},
+	0x2D: {"SRA L", 8, func(z *SM83) { z.L = z.sra(z.L) }},
+	0x2E: {"SRA (HL)", 16, func(z *SM83) { z.write(z.HL(), z.sra(z.read(z.HL()))) }},
+	0x2F: {"SRA A", 8, func(z *SM83) { z.A = z.sra(z.A) }},
+
+	0x30: {"SWAP B", 8, func(z *SM83) { z.B = z.swap(z.B) }},
+	0x31: {"SWAP C", 8, func(z *SM83) { z.C = z.swap(z.C) }},
+	0x32: {"SWAP D", 8, func(z *SM83) { z.D = z.swap(z.D) }},
+	0x33: {"SWAP E", 8, func(z *SM83) { z.E = z.swap(z.E) }},
+	0x34: {"SWAP H", 8, func(z *SM83) { z.H = z.swap(z.H) }},
+	0x35: {"SWAP L", 8, func(z *SM83) { z.L = z.swap(z.L) }},
+	0x36: {"SWAP (HL)", 16, func(z *SM83) { z.write(z.HL(), z.swap(z.read(z.HL()))) }},
+	0x37: {"SWAP A", 8, func(z *SM83) { z.A = z.swap(z.A) }},
+
+	0x38: {"SRL B", 8, func(z *SM83) { z.B = z.srl(z.B) }},
+	0x39: {"SRL C", 8, func(z *SM83) { z.C = z.srl(z.C) }},
+	0x3A: {"SRL D", 8, func(z *SM83) { z.D = z.srl(z.D) }},
+	0x3B: {"SRL E", 8, func(z *SM83) { z.E = z.srl(z.E) }},
+	0x3C: {"SRL H", 8, func(z *SM83) { z.H = z.srl(z.H) }},
+	0x3D: {"SRL L", 8, func(z *SM83) { z.L = z.srl(z.L) }},
+	0x3E: {"SRL (HL)", 16, func(z *SM83) { z.write(z.HL(), z.srl(z.read(z.HL()))) }},
+	0x3F: {"SRL A", 8, func(z *SM83) { z.A = z.srl(z.A) }},
+
+	// Bit Opcodes
+	0x40: {"BIT 0, B", 8, func(z *SM83) { z.bit(0, z.B) }},
+	0x41: {"BIT 0, C", 8, func(z *SM83) { z.bit(0, z.C) }},
+	0x42: {"BIT 0, D", 8, func(z *SM83) { z.bit(0, z.D) }},
+	0x43: {"BIT 0, E", 8, func(z *SM83) { z.bit(0, z.E) }},
+	0x44: {"BIT 0, H", 8, func(z *SM83) { z.bit(0, z.H) }},
+	0x45: {"BIT 0, L", 8, func(z *SM83) { z.bit(0, z.L) }},
+	0x46: {"BIT 0, (HL)", 12, func(z *SM83) { z.bit(0, z.read(z.HL())) }},
+	0x47: {"BIT 0, A", 8, func(z *SM83) { z.bit(0, z.A) }},
+
+	0x48: {"BIT 1, B", 8, func(z *SM83) { z.bit(1, z.B) }},
+	0x49: {"BIT 1, C", 8, func(z *SM83) { z.bit(1, z.C) }},
+	0x4A: {"BIT 1, D", 8, func(z *SM83) { z.bit(1, z.D) }},
+	0x4B: {"BIT 1, E", 8, func(z *SM83) { z.bit(1, z.E) }},
+	0x4C: {"BIT 1, H", 8, func(z *SM83) { z.bit(1, z.H) }},
+	0x4D: {"BIT 1, L", 8, func(z *SM83) { z.bit(1, z.L) }},
+	0x4E: {"BIT 1, (HL)", 12, func(z *SM83) { z.bit(1, z.read(z.HL())) }},
+	0x4F: {"BIT 1, A", 8, func(z *SM83) { z.bit(1, z.A) }},
+
+	0x50: {"BIT 2, B", 8, func(z *SM83) { z.bit(2, z.B) }},
+	0x51: {"BIT 2, C", 8, func(z *SM83) { z.bit(2, z.C) }},
+	0x52: {"BIT 2, D", 8, func(z *SM83) { z.bit(2, z.D) }},
+	0x53: {"BIT 2, E", 8, func(z *SM83) { z.bit(2, z.E) }},
+	0x54: {"BIT 2, H", 8, func(z *SM83) { z.bit(2, z.H) }},
+	0x55: {"BIT 2, L", 8, func(z *SM83) { z.bit(2, z.L) }},
+	0x56: {"BIT 2, (HL)", 12, func(z *SM83) { z.bit(2, z.read(z.HL())) }},
+	0x57: {"BIT 2, A", 8, func(z *SM83) { z.bit(2, z.A) }},
+
+	0x58: {"BIT 3, B", 8, func(z *SM83) { z.bit(3, z.B) }},
+	0x59: {"BIT 3, C", 8, func(z *SM83) { z.bit(3, z.C) }},
+	0x5A: {"BIT 3, D", 8, func(z *SM83) { z.bit(3, z.D) }},
+	0x5B: {"BIT 3, E", 8, func(z *SM83) { z.bit(3, z.E) }},
+	0x5C: {"BIT 3, H", 8, func(z *SM83) { z.bit(3, z.H) }},
+	0x5D: {"BIT 3, L", 8, func(z *SM83) { z.bit(3, z.L) }},
+	0x5E: {"BIT 3, (HL)", 12, func(z *SM83) { z.bit(3, z.read(z.HL())) }},
+	0x5F: {"BIT 3, A", 8, func(z *SM83) { z.bit(3, z.A) }},
+
+	0x60: {"BIT 4, B", 8, func(z *SM83) { z.bit(4, z.B) }},
+	0x61: {"BIT 4, C", 8, func(z *SM83) { z.bit(4, z.C) }},
+	0x62: {"BIT 4, D", 8, func(z *SM83) { z.bit(4, z.D) }},
+	0x63: {"BIT 4, E", 8, func(z *SM83) { z.bit(4, z.E) }},
+	0x64: {"BIT 4, H", 8, func(z *SM83) { z.bit(4, z.H) }},
+	0x65: {"BIT 4, L", 8, func(z *SM83) { z.bit(4, z.L) }},
+	0x66: {"BIT 4, (HL)", 12, func(z *SM83) { z.bit(4, z.read(z.HL())) }},
+	0x67: {"BIT 4, A", 8, func(z *SM83) { z.bit(4, z.A) }},
+
+	0x68: {"BIT 5, B", 8, func(z *SM83) { z.bit(5, z.B) }},
+	0x69: {"BIT 5, C", 8, func(z *SM83) { z.bit(5, z.C) }},
+	0x6A: {"BIT 5, D", 8, func(z *SM83) { z.bit(5, z.D) }},
+	0x6B: {"BIT 5, E", 8, func(z *SM83) { z.bit(5, z.E) }},
+	0x6C: {"BIT 5, H", 8, func(z *SM83) { z.bit(5, z.H) }},
+	0x6D: {"BIT 5, L", 8, func(z *SM83) { z.bit(5, z.L) }},
+	0x6E: {"BIT 5, (HL)", 12, func(z *SM83) { z.bit(5, z.read(z.HL())) }},
+	0x6F: {"BIT 5, A", 8, func(z *SM83) { z.bit(5, z.A) }},
+
+	0x70: {"BIT 6, B", 8, func(z *SM83) { z.bit(6, z.B) }},
+	0x71: {"BIT 6, C", 8, func(z *SM83) { z.bit(6, z.C) }},
+	0x72: {"BIT 6, D", 8, func(z *SM83) { z.bit(6, z.D) }},
+	0x73: {"BIT 6, E", 8, func(z *SM83) { z.bit(6, z.E) }},
+	0x74: {"BIT 6, H", 8, func(z *SM83) { z.bit(6, z.H) }},
+	0x75: {"BIT 6, L", 8, func(z *SM83) { z.bit(6, z.L) }},
+	0x76: {"BIT 6, (HL)", 12, func(z *SM83) { z.bit(6, z.read(z.HL())) }},
+	0x77: {"BIT 6, A", 8, func(z *SM83) { z.bit(6, z.A) }},
+
+	0x78: {"BIT 7, B", 8, func(z *SM83) { z.bit(7, z.B) }},
+	0x79: {"BIT 7, C", 8, func(z *SM83) { z.bit(7, z.C) }},
+	0x7A: {"BIT 7, D", 8, func(z *SM83) { z.bit(7, z.D) }},
+	0x7B: {"BIT 7, E", 8, func(z *SM83) { z.bit(7, z.E) }},
+	0x7C: {"BIT 7, H", 8, func(z *SM83) { z.bit(7, z.H) }},
+	0x7D: {"BIT 7, L", 8, func(z *SM83) { z.bit(7, z.L) }},
+	0x7E: {"BIT 7, (HL)", 12, func(z *SM83) { z.bit(7, z.read(z.HL())) }},
+	0x7F: {"BIT 7, A", 8, func(z *SM83) { z.bit(7, z.A) }},
+
+	0x80: {"RES 0, B", 8, func(z *SM83) { z.B = z.res(0, z.B) }},
+	0x81: {"RES 0, C", 8, func(z *SM83) { z.C = z.res(0, z.C) }},
+	0x82: {"RES 0, D", 8, func(z *SM83) { z.D = z.res(0, z.D) }},
+	0x83: {"RES 0, E", 8, func(z *SM83) { z.E = z.res(0, z.E) }},
+	0x84: {"RES 0, H", 8, func(z *SM83) { z.H = z.res(0, z.H) }},
+	0x85: {"RES 0, L", 8, func(z *SM83) { z.L = z.res(0, z.L) }},
+	0x86: {"RES 0, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(0, z.read(z.HL()))) }},
+	0x87: {"RES 0, A", 8, func(z *SM83) { z.A = z.res(0, z.A) }},
+
+	0x88: {"RES 1, B", 8, func(z *SM83) { z.B = z.res(1, z.B) }},
+	0x89: {"RES 1, C", 8, func(z *SM83) { z.C = z.res(1, z.C) }},
+	0x8A: {"RES 1, D", 8, func(z *SM83) { z.D = z.res(1, z.D) }},
+	0x8B: {"RES 1, E", 8, func(z *SM83) { z.E = z.res(1, z.E) }},
+	0x8C: {"RES 1, H", 8, func(z *SM83) { z.H = z.res(1, z.H) }},
+	0x8D: {"RES 1, L", 8, func(z *SM83) { z.L = z.res(1, z.L) }},
+	0x8E: {"RES 1, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(1, z.read(z.HL()))) }},
+	0x8F: {"RES 1, A", 8, func(z *SM83) { z.A = z.res(1, z.A) }},
+
+	0x90: {"RES 2, B", 8, func(z *SM83) { z.B = z.res(2, z.B) }},
+	0x91: {"RES 2, C", 8, func(z *SM83) { z.C = z.res(2, z.C) }},
+	0x92: {"RES 2, D", 8, func(z *SM83) { z.D = z.res(2, z.D) }},
+	0x93: {"RES 2, E", 8, func(z *SM83) { z.E = z.res(2, z.E) }},
+	0x94: {"RES 2, H", 8, func(z *SM83) { z.H = z.res(2, z.H) }},
+	0x95: {"RES 2, L", 8, func(z *SM83) { z.L = z.res(2, z.L) }},
+	0x96: {"RES 2, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(2, z.read(z.HL()))) }},
+	0x97: {"RES 2, A", 8, func(z *SM83) { z.A = z.res(2, z.A) }},
+
+	0x98: {"RES 3, B", 8, func(z *SM83) { z.B = z.res(3, z.B) }},
+	0x99: {"RES 3, C", 8, func(z *SM83) { z.C = z.res(3, z.C) }},
+	0x9A: {"RES 3, D", 8, func(z *SM83) { z.D = z.res(3, z.D) }},
+	0x9B: {"RES 3, E", 8, func(z *SM83) { z.E = z.res(3, z.E) }},
+	0x9C: {"RES 3, H", 8, func(z *SM83) { z.H = z.res(3, z.H) }},
+	0x9D: {"RES 3, L", 8, func(z *SM83) { z.L = z.res(3, z.L) }},
+	0x9E: {"RES 3, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(3, z.read(z.HL()))) }},
+	0x9F: {"RES 3, A", 8, func(z *SM83) { z.A = z.res(3, z.A) }},
+
+	0xA0: {"RES 4, B", 8, func(z *SM83) { z.B = z.res(4, z.B) }},
+	0xA1: {"RES 4, C", 8, func(z *SM83) { z.C = z.res(4, z.C) }},
+	0xA2: {"RES 4, D", 8, func(z *SM83) { z.D = z.res(4, z.D) }},
+	0xA3: {"RES 4, E", 8, func(z *SM83) { z.E = z.res(4, z.E) }},
+	0xA4: {"RES 4, H", 8, func(z *SM83) { z.H = z.res(4, z.H) }},
+	0xA5: {"RES 4, L", 8, func(z *SM83) { z.L = z.res(4, z.L) }},
+	0xA6: {"RES 4, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(4, z.read(z.HL()))) }},
+	0xA7: {"RES 4, A", 8, func(z *SM83) { z.A = z.res(4, z.A) }},
+
+	0xA8: {"RES 5, B", 8, func(z *SM83) { z.B = z.res(5, z.B) }},
+	0xA9: {"RES 5, C", 8, func(z *SM83) { z.C = z.res(5, z.C) }},
+	0xAA: {"RES 5, D", 8, func(z *SM83) { z.D = z.res(5, z.D) }},
+	0xAB: {"RES 5, E", 8, func(z *SM83) { z.E = z.res(5, z.E) }},
+	0xAC: {"RES 5, H", 8, func(z *SM83) { z.H = z.res(5, z.H) }},
+	0xAD: {"RES 5, L", 8, func(z *SM83) { z.L = z.res(5, z.L) }},
+	0xAE: {"RES 5, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(5, z.read(z.HL()))) }},
+	0xAF: {"RES 5, A", 8, func(z *SM83) { z.A = z.res(5, z.A) }},
+
+	0xB0: {"RES 6, B", 8, func(z *SM83) { z.B = z.res(6, z.B) }},
+	0xB1: {"RES 6, C", 8, func(z *SM83) { z.C = z.res(6, z.C) }},
+	0xB2: {"RES 6, D", 8, func(z *SM83) { z.D = z.res(6, z.D) }},
+	0xB3: {"RES 6, E", 8, func(z *SM83) { z.E = z.res(6, z.E) }},
+	0xB4: {"RES 6, H", 8, func(z *SM83) { z.H = z.res(6, z.H) }},
+	0xB5: {"RES 6, L", 8, func(z *SM83) { z.L = z.res(6, z.L) }},
+	0xB6: {"RES 6, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(6, z.read(z.HL()))) }},
+	0xB7: {"RES 6, A", 8, func(z *SM83) { z.A = z.res(6, z.A) }},
+
+	0xB8: {"RES 7, B", 8, func(z *SM83) { z.B = z.res(7, z.B) }},
+	0xB9: {"RES 7, C", 8, func(z *SM83) { z.C = z.res(7, z.C) }},
+	0xBA: {"RES 7, D", 8, func(z *SM83) { z.D = z.res(7, z.D) }},
+	0xBB: {"RES 7, E", 8, func(z *SM83) { z.E = z.res(7, z.E) }},
+	0xBC: {"RES 7, H", 8, func(z *SM83) { z.H = z.res(7, z.H) }},
+	0xBD: {"RES 7, L", 8, func(z *SM83) { z.L = z.res(7, z.L) }},
+	0xBE: {"RES 7, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.res(7, z.read(z.HL()))) }},
+	0xBF: {"RES 7, A", 8, func(z *SM83) { z.A = z.res(7, z.A) }},
+
+	0xC0: {"SET 0, B", 8, func(z *SM83) { z.B = z.set(0, z.B) }},
+	0xC1: {"SET 0, C", 8, func(z *SM83) { z.C = z.set(0, z.C) }},
+	0xC2: {"SET 0, D", 8, func(z *SM83) { z.D = z.set(0, z.D) }},
+	0xC3: {"SET 0, E", 8, func(z *SM83) { z.E = z.set(0, z.E) }},
+	0xC4: {"SET 0, H", 8, func(z *SM83) { z.H = z.set(0, z.H) }},
+	0xC5: {"SET 0, L", 8, func(z *SM83) { z.L = z.set(0, z.L) }},
+	0xC6: {"SET 0, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(0, z.read(z.HL()))) }},
+	0xC7: {"SET 0, A", 8, func(z *SM83) { z.A = z.set(0, z.A) }},
+
+	0xC8: {"SET 1, B", 8, func(z *SM83) { z.B = z.set(1, z.B) }},
+	0xC9: {"SET 1, C", 8, func(z *SM83) { z.C = z.set(1, z.C) }},
+	0xCA: {"SET 1, D", 8, func(z *SM83) { z.D = z.set(1, z.D) }},
+	0xCB: {"SET 1, E", 8, func(z *SM83) { z.E = z.set(1, z.E) }},
+	0xCC: {"SET 1, H", 8, func(z *SM83) { z.H = z.set(1, z.H) }},
+	0xCD: {"SET 1, L", 8, func(z *SM83) { z.L = z.set(1, z.L) }},
+	0xCE: {"SET 1, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(1, z.read(z.HL()))) }},
+	0xCF: {"SET 1, A", 8, func(z *SM83) { z.A = z.set(1, z.A) }},
+
+	0xD0: {"SET 2, B", 8, func(z *SM83) { z.B = z.set(2, z.B) }},
+	0xD1: {"SET 2, C", 8, func(z *SM83) { z.C = z.set(2, z.C) }},
+	0xD2: {"SET 2, D", 8, func(z *SM83) { z.D = z.set(2, z.D) }},
+	0xD3: {"SET 2, E", 8, func(z *SM83) { z.E = z.set(2, z.E) }},
+	0xD4: {"SET 2, H", 8, func(z *SM83) { z.H = z.set(2, z.H) }},
+	0xD5: {"SET 2, L", 8, func(z *SM83) { z.L = z.set(2, z.L) }},
+	0xD6: {"SET 2, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(2, z.read(z.HL()))) }},
+	0xD7: {"SET 2, A", 8, func(z *SM83) { z.A = z.set(2, z.A) }},
+
+	0xD8: {"SET 3, B", 8, func(z *SM83) { z.B = z.set(3, z.B) }},
+	0xD9: {"SET 3, C", 8, func(z *SM83) { z.C = z.set(3, z.C) }},
+	0xDA: {"SET 3, D", 8, func(z *SM83) { z.D = z.set(3, z.D) }},
+	0xDB: {"SET 3, E", 8, func(z *SM83) { z.E = z.set(3, z.E) }},
+	0xDC: {"SET 3, H", 8, func(z *SM83) { z.H = z.set(3, z.H) }},
+	0xDD: {"SET 3, L", 8, func(z *SM83) { z.L = z.set(3, z.L) }},
+	0xDE: {"SET 3, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(3, z.read(z.HL()))) }},
+	0xDF: {"SET 3, A", 8, func(z *SM83) { z.A = z.set(3, z.A) }},
+
+	0xE0: {"SET 4, B", 8, func(z *SM83) { z.B = z.set(4, z.B) }},
+	0xE1: {"SET 4, C", 8, func(z *SM83) { z.C = z.set(4, z.C) }},
+	0xE2: {"SET 4, D", 8, func(z *SM83) { z.D = z.set(4, z.D) }},
+	0xE3: {"SET 4, E", 8, func(z *SM83) { z.E = z.set(4, z.E) }},
+	0xE4: {"SET 4, H", 8, func(z *SM83) { z.H = z.set(4, z.H) }},
+	0xE5: {"SET 4, L", 8, func(z *SM83) { z.L = z.set(4, z.L) }},
+	0xE6: {"SET 4, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(4, z.read(z.HL()))) }},
+	0xE7: {"SET 4, A", 8, func(z *SM83) { z.A = z.set(4, z.A) }},
+
+	0xE8: {"SET 5, B", 8, func(z *SM83) { z.B = z.set(5, z.B) }},
+	0xE9: {"SET 5, C", 8, func(z *SM83) { z.C = z.set(5, z.C) }},
+	0xEA: {"SET 5, D", 8, func(z *SM83) { z.D = z.set(5, z.D) }},
+	0xEB: {"SET 5, E", 8, func(z *SM83) { z.E = z.set(5, z.E) }},
+	0xEC: {"SET 5, H", 8, func(z *SM83) { z.H = z.set(5, z.H) }},
+	0xED: {"SET 5, L", 8, func(z *SM83) { z.L = z.set(5, z.L) }},
+	0xEE: {"SET 5, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(5, z.read(z.HL()))) }},
+	0xEF: {"SET 5, A", 8, func(z *SM83) { z.A = z.set(5, z.A) }},
+
+	0xF0: {"SET 6, B", 8, func(z *SM83) { z.B = z.set(6, z.B) }},
+	0xF1: {"SET 6, C", 8, func(z *SM83) { z.C = z.set(6, z.C) }},
+	0xF2: {"SET 6, D", 8, func(z *SM83) { z.D = z.set(6, z.D) }},
+	0xF3: {"SET 6, E", 8, func(z *SM83) { z.E = z.set(6, z.E) }},
+	0xF4: {"SET 6, H", 8, func(z *SM83) { z.H = z.set(6, z.H) }},
+	0xF5: {"SET 6, L", 8, func(z *SM83) { z.L = z.set(6, z.L) }},
+	0xF6: {"SET 6, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(6, z.read(z.HL()))) }},
+	0xF7: {"SET 6, A", 8, func(z *SM83) { z.A = z.set(6, z.A) }},
+
+	0xF8: {"SET 7, B", 8, func(z *SM83) { z.B = z.set(7, z.B) }},
+	0xF9: {"SET 7, C", 8, func(z *SM83) { z.C = z.set(7, z.C) }},
+	0xFA: {"SET 7, D", 8, func(z *SM83) { z.D = z.set(7, z.D) }},
+	0xFB: {"SET 7, E", 8, func(z *SM83) { z.E = z.set(7, z.E) }},
+	0xFC: {"SET 7, H", 8, func(z *SM83) { z.H = z.set(7, z.H) }},
+	0xFD: {"SET 7, L", 8, func(z *SM83) { z.L = z.set(7, z.L) }},
+	0xFE: {"SET 7, (HL)", 16, func(z *SM83) { z.write(z.HL(), z.set(7, z.read(z.HL()))) }},
+	0xFF: {"SET 7, A", 8, func(z *SM83) { z.A = z.set(7, z.A) }},
 }
@@ -9,7 +9,7 @@ import (
 func TestNOP(t *testing.T) {
 	input := []byte{0, 0}
 	expected := uint16(2)
-	z := NewZ80()
+	z := NewSM83()
 	z.LoadProgram(input, 0)
 	z.step()
 	z.step()
@@ -18,8 +18,26 @@ func TestNOP(t *testing.T) {
 	}
 }
 
+func TestSetAF(t *testing.T) {
+	z := NewSM83()
+	z.SetAF(0x12FF)
+	if z.A != 0x12 || z.F != 0xF0 {
+		t.Errorf("expected A=12 F=F0, got A=%02X F=%02X", z.A, z.F)
+	}
+
+	// PUSH BC; POP AF
+	z.SetBC(0x34FF)
+	z.SP = 0xD000
+	z.LoadProgram([]byte{0xC5, 0xF1}, 0x0200)
+	z.step()
+	z.step()
+	if z.AF() != 0x34F0 {
+		t.Errorf("expected AF=34F0 after POP AF, got %04X", z.AF())
+	}
+}
+
 func TestLD(t *testing.T) {
-	z := NewZ80()
+	z := NewSM83()
 	tbl := []struct {
 		name     string
 		program  []byte
@@ -87,44 +105,44 @@ func TestLDA(t *testing.T) {
 		{
 			name:     "LD A,A",
 			program:  []byte{0x7F},
-			input:    Z80{A: 0xAA},
-			expected: Z80{A: 0xAA},
+			input:    SM83{A: 0xAA},
+			expected: SM83{A: 0xAA},
 		},
 		{
 			name:     "LD A,B",
 			program:  []byte{0x78},
-			input:    Z80{B: 0xDE},
-			expected: Z80{A: 0xDE},
+			input:    SM83{B: 0xDE},
+			expected: SM83{A: 0xDE},
 		},
 		{
 			name:     "LD A,C",
 			program:  []byte{0x79},
-			input:    Z80{C: 0xAD},
-			expected: Z80{A: 0xAD},
+			input:    SM83{C: 0xAD},
+			expected: SM83{A: 0xAD},
 		},
 		{
 			name:     "LD A,D",
 			program:  []byte{0x7A},
-			input:    Z80{D: 0xBE},
-			expected: Z80{A: 0xBE},
+			input:    SM83{D: 0xBE},
+			expected: SM83{A: 0xBE},
 		},
 		{
 			name:     "LD A,E",
 			program:  []byte{0x7B},
-			input:    Z80{E: 0xEF},
-			expected: Z80{A: 0xEF},
+			input:    SM83{E: 0xEF},
+			expected: SM83{A: 0xEF},
 		},
 		{
 			name:     "LD A,H",
 			program:  []byte{0x7C},
-			input:    Z80{H: 0xCA},
-			expected: Z80{A: 0xCA},
+			input:    SM83{H: 0xCA},
+			expected: SM83{A: 0xCA},
 		},
 		{
 			name:     "LD A,L",
 			program:  []byte{0x7D},
-			input:    Z80{L: 0xFE},
-			expected: Z80{A: 0xFE},
+			input:    SM83{L: 0xFE},
+			expected: SM83{A: 0xFE},
 		},
 	}
 
@@ -155,14 +173,14 @@ func TestLDH(t *testing.T) {
 		{
 			name:     "LD H, (HL)",
 			program:  []byte{0x66, 0x00, 0x00, 0xCA, 0xFE},
-			input:    Z80{L: 4},
-			expected: Z80{H: 0xFE},
+			input:    SM83{L: 4},
+			expected: SM83{H: 0xFE},
 		},
 		{
 			name:     "LD H, (HL)",
 			program:  []byte{0x66, 0x00, 0x00, 0xCA, 0xFE},
-			input:    Z80{H: 4, L: 1},
-			expected: Z80{H: 0x00},
+			input:    SM83{H: 4, L: 1},
+			expected: SM83{H: 0x00},
 		},
 	}
 
@@ -188,56 +206,857 @@ func TestLDH(t *testing.T) {
 	}
 }
 
+func TestLDrr(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "LD B,B",
+			program:  []byte{0x40},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,C",
+			program:  []byte{0x41},
+			input:    SM83{C: 0xC0},
+			expected: SM83{B: 0xC0, C: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,D",
+			program:  []byte{0x42},
+			input:    SM83{D: 0xD0},
+			expected: SM83{B: 0xD0, D: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,E",
+			program:  []byte{0x43},
+			input:    SM83{E: 0xE0},
+			expected: SM83{B: 0xE0, E: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,H",
+			program:  []byte{0x44},
+			input:    SM83{H: 0x40},
+			expected: SM83{B: 0x40, H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,L",
+			program:  []byte{0x45},
+			input:    SM83{L: 0x50},
+			expected: SM83{B: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD B,A",
+			program:  []byte{0x47},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, B: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,B",
+			program:  []byte{0x48},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0, C: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,C",
+			program:  []byte{0x49},
+			input:    SM83{C: 0xC0},
+			expected: SM83{C: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,D",
+			program:  []byte{0x4A},
+			input:    SM83{D: 0xD0},
+			expected: SM83{C: 0xD0, D: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,E",
+			program:  []byte{0x4B},
+			input:    SM83{E: 0xE0},
+			expected: SM83{C: 0xE0, E: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,H",
+			program:  []byte{0x4C},
+			input:    SM83{H: 0x40},
+			expected: SM83{C: 0x40, H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,L",
+			program:  []byte{0x4D},
+			input:    SM83{L: 0x50},
+			expected: SM83{C: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD C,A",
+			program:  []byte{0x4F},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, C: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,B",
+			program:  []byte{0x50},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0, D: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,C",
+			program:  []byte{0x51},
+			input:    SM83{C: 0xC0},
+			expected: SM83{C: 0xC0, D: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,D",
+			program:  []byte{0x52},
+			input:    SM83{D: 0xD0},
+			expected: SM83{D: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,E",
+			program:  []byte{0x53},
+			input:    SM83{E: 0xE0},
+			expected: SM83{D: 0xE0, E: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,H",
+			program:  []byte{0x54},
+			input:    SM83{H: 0x40},
+			expected: SM83{D: 0x40, H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,L",
+			program:  []byte{0x55},
+			input:    SM83{L: 0x50},
+			expected: SM83{D: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD D,A",
+			program:  []byte{0x57},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, D: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,B",
+			program:  []byte{0x58},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0, E: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,C",
+			program:  []byte{0x59},
+			input:    SM83{C: 0xC0},
+			expected: SM83{C: 0xC0, E: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,D",
+			program:  []byte{0x5A},
+			input:    SM83{D: 0xD0},
+			expected: SM83{D: 0xD0, E: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,E",
+			program:  []byte{0x5B},
+			input:    SM83{E: 0xE0},
+			expected: SM83{E: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,H",
+			program:  []byte{0x5C},
+			input:    SM83{H: 0x40},
+			expected: SM83{E: 0x40, H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,L",
+			program:  []byte{0x5D},
+			input:    SM83{L: 0x50},
+			expected: SM83{E: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD E,A",
+			program:  []byte{0x5F},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, E: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,B",
+			program:  []byte{0x60},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0, H: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,C",
+			program:  []byte{0x61},
+			input:    SM83{C: 0xC0},
+			expected: SM83{C: 0xC0, H: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,D",
+			program:  []byte{0x62},
+			input:    SM83{D: 0xD0},
+			expected: SM83{D: 0xD0, H: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,E",
+			program:  []byte{0x63},
+			input:    SM83{E: 0xE0},
+			expected: SM83{E: 0xE0, H: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,H",
+			program:  []byte{0x64},
+			input:    SM83{H: 0x40},
+			expected: SM83{H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,L",
+			program:  []byte{0x65},
+			input:    SM83{L: 0x50},
+			expected: SM83{H: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD H,A",
+			program:  []byte{0x67},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, H: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,B",
+			program:  []byte{0x68},
+			input:    SM83{B: 0xB0},
+			expected: SM83{B: 0xB0, L: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,C",
+			program:  []byte{0x69},
+			input:    SM83{C: 0xC0},
+			expected: SM83{C: 0xC0, L: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,D",
+			program:  []byte{0x6A},
+			input:    SM83{D: 0xD0},
+			expected: SM83{D: 0xD0, L: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,E",
+			program:  []byte{0x6B},
+			input:    SM83{E: 0xE0},
+			expected: SM83{E: 0xE0, L: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,H",
+			program:  []byte{0x6C},
+			input:    SM83{H: 0x40},
+			expected: SM83{H: 0x40, L: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,L",
+			program:  []byte{0x6D},
+			input:    SM83{L: 0x50},
+			expected: SM83{L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD L,A",
+			program:  []byte{0x6F},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0, L: 0xA0},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,B",
+			program:  []byte{0x78},
+			input:    SM83{B: 0xB0},
+			expected: SM83{A: 0xB0, B: 0xB0},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,C",
+			program:  []byte{0x79},
+			input:    SM83{C: 0xC0},
+			expected: SM83{A: 0xC0, C: 0xC0},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,D",
+			program:  []byte{0x7A},
+			input:    SM83{D: 0xD0},
+			expected: SM83{A: 0xD0, D: 0xD0},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,E",
+			program:  []byte{0x7B},
+			input:    SM83{E: 0xE0},
+			expected: SM83{A: 0xE0, E: 0xE0},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,H",
+			program:  []byte{0x7C},
+			input:    SM83{H: 0x40},
+			expected: SM83{A: 0x40, H: 0x40},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,L",
+			program:  []byte{0x7D},
+			input:    SM83{L: 0x50},
+			expected: SM83{A: 0x50, L: 0x50},
+			cycles:   4,
+		},
+		{
+			name:     "LD A,A",
+			program:  []byte{0x7F},
+			input:    SM83{A: 0xA0},
+			expected: SM83{A: 0xA0},
+			cycles:   4,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestLDrHL(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "LD B, (HL)",
+			program:  []byte{0x46, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{B: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+		{
+			name:     "LD C, (HL)",
+			program:  []byte{0x4E, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{C: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+		{
+			name:     "LD D, (HL)",
+			program:  []byte{0x56, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{D: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+		{
+			name:     "LD E, (HL)",
+			program:  []byte{0x5E, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{E: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+		{
+			name:     "LD H, (HL)",
+			program:  []byte{0x66, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{H: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+		{
+			name:     "LD L, (HL)",
+			program:  []byte{0x6E, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{L: 0xCA},
+			cycles:   8,
+		},
+		{
+			name:     "LD A, (HL)",
+			program:  []byte{0x7E, 0x00, 0x00, 0xCA},
+			input:    SM83{L: 0x03},
+			expected: SM83{A: 0xCA, L: 0x03},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestLDHLr(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		input    SM83
+		addr     uint16
+		expected byte
+		cycles   int
+	}{
+		{
+			name:     "LD (HL), B",
+			program:  []byte{0x70},
+			input:    SM83{B: 0xB0, H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xB0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), C",
+			program:  []byte{0x71},
+			input:    SM83{C: 0xC0, H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xC0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), D",
+			program:  []byte{0x72},
+			input:    SM83{D: 0xD0, H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xD0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), E",
+			program:  []byte{0x73},
+			input:    SM83{E: 0xE0, H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xE0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), H",
+			program:  []byte{0x74},
+			input:    SM83{H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xC0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), L",
+			program:  []byte{0x75},
+			input:    SM83{H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0x10,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), A",
+			program:  []byte{0x77},
+			input:    SM83{A: 0xA0, H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0xA0,
+			cycles:   8,
+		},
+		{
+			name:     "LD (HL), n",
+			program:  []byte{0x36, 0x42},
+			input:    SM83{H: 0xC0, L: 0x10},
+			addr:     0xC010,
+			expected: 0x42,
+			cycles:   12,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if value := m.Read(tc.addr); value != tc.expected {
+				t.Errorf("expected (%x)=%x, got %x", tc.addr, tc.expected, value)
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestLDAmem(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		input    SM83
+		addr     uint16
+		value    byte
+		expected SM83
+		cycles   int
+	}{
+		{
+			name:     "LD A, (BC)",
+			program:  []byte{0x0A},
+			input:    SM83{B: 0xC0, C: 0x01},
+			addr:     0xC001,
+			value:    0xCA,
+			expected: SM83{A: 0xCA, B: 0xC0, C: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "LD A, (DE)",
+			program:  []byte{0x1A},
+			input:    SM83{D: 0xC0, E: 0x02},
+			addr:     0xC002,
+			value:    0xFE,
+			expected: SM83{A: 0xFE, D: 0xC0, E: 0x02},
+			cycles:   8,
+		},
+		{
+			name:     "LD A, (nn)",
+			program:  []byte{0xFA, 0x03, 0xC0},
+			input:    SM83{},
+			addr:     0xC003,
+			value:    0xBE,
+			expected: SM83{A: 0xBE},
+			cycles:   16,
+		},
+		{
+			name:     "LDH A, (n)",
+			program:  []byte{0xF0, 0x44},
+			input:    SM83{},
+			addr:     0xFF44,
+			value:    0x90,
+			expected: SM83{A: 0x90},
+			cycles:   12,
+		},
+		{
+			name:     "LD A, (C)",
+			program:  []byte{0xF2},
+			input:    SM83{C: 0x80},
+			addr:     0xFF80,
+			value:    0xEF,
+			expected: SM83{A: 0xEF, C: 0x80},
+			cycles:   8,
+		},
+		{
+			name:     "LDI A, (HL)",
+			program:  []byte{0x2A},
+			input:    SM83{H: 0xC0, L: 0xFF},
+			addr:     0xC0FF,
+			value:    0x11,
+			expected: SM83{A: 0x11, H: 0xC1, L: 0x00},
+			cycles:   8,
+		},
+		{
+			name:     "LDD A, (HL)",
+			program:  []byte{0x3A},
+			input:    SM83{H: 0xC1, L: 0x00},
+			addr:     0xC100,
+			value:    0x22,
+			expected: SM83{A: 0x22, H: 0xC0, L: 0xFF},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+			m.Write(tc.addr, tc.value)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
+func TestLDmemA(t *testing.T) {
+	tbl := []struct {
+		name     string
+		program  []byte
+		input    SM83
+		addr     uint16
+		expected SM83
+		cycles   int
+	}{
+		{
+			name:     "LD (BC), A",
+			program:  []byte{0x02},
+			input:    SM83{A: 0xCA, B: 0xC0, C: 0x01},
+			addr:     0xC001,
+			expected: SM83{A: 0xCA, B: 0xC0, C: 0x01},
+			cycles:   8,
+		},
+		{
+			name:     "LD (DE), A",
+			program:  []byte{0x12},
+			input:    SM83{A: 0xFE, D: 0xC0, E: 0x02},
+			addr:     0xC002,
+			expected: SM83{A: 0xFE, D: 0xC0, E: 0x02},
+			cycles:   8,
+		},
+		{
+			name:     "LD (nn), A",
+			program:  []byte{0xEA, 0x03, 0xC0},
+			input:    SM83{A: 0xBE},
+			addr:     0xC003,
+			expected: SM83{A: 0xBE},
+			cycles:   16,
+		},
+		{
+			name:     "LDH (n), A",
+			program:  []byte{0xE0, 0x40},
+			input:    SM83{A: 0x91},
+			addr:     0xFF40,
+			expected: SM83{A: 0x91},
+			cycles:   12,
+		},
+		{
+			name:     "LD (C), A",
+			program:  []byte{0xE2},
+			input:    SM83{A: 0xEF, C: 0x80},
+			addr:     0xFF80,
+			expected: SM83{A: 0xEF, C: 0x80},
+			cycles:   8,
+		},
+		{
+			name:     "LDI (HL), A",
+			program:  []byte{0x22},
+			input:    SM83{A: 0x11, H: 0xC0, L: 0xFF},
+			addr:     0xC0FF,
+			expected: SM83{A: 0x11, H: 0xC1, L: 0x00},
+			cycles:   8,
+		},
+		{
+			name:     "LDD (HL), A",
+			program:  []byte{0x32},
+			input:    SM83{A: 0x22, H: 0xC1, L: 0x00},
+			addr:     0xC100,
+			expected: SM83{A: 0x22, H: 0xC0, L: 0xFF},
+			cycles:   8,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			m := memory.NewMemory()
+			tc.input.ram = m
+			tc.input.LoadProgram(tc.program, 0)
+
+			err := tc.input.step()
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if value := m.Read(tc.addr); value != tc.expected.A {
+				t.Errorf("expected (%x)=%x, got %x", tc.addr, tc.expected.A, value)
+			}
+
+			if tc.input.AF() != tc.expected.AF() {
+				t.Errorf("expected AF %x, got %x", tc.expected.AF(), tc.input.AF())
+			}
+
+			if tc.input.BC() != tc.expected.BC() {
+				t.Errorf("expected BC %x, got %x", tc.expected.BC(), tc.input.BC())
+			}
+
+			if tc.input.DE() != tc.expected.DE() {
+				t.Errorf("expected DE %x, got %x", tc.expected.DE(), tc.input.DE())
+			}
+
+			if tc.input.HL() != tc.expected.HL() {
+				t.Errorf("expected HL %x, got %x", tc.expected.HL(), tc.input.HL())
+			}
+
+			if tc.input.cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, tc.input.cycles)
+			}
+		})
+	}
+}
+
 // 16-Bit Loads
 func TestLD16(t *testing.T) {
 	tbl := []testcase{
 		{
 			name:     "LD BC, nn",
-			program:  []byte{0x01, 0x01, 0xFF},
-			input:    Z80{},
-			expected: Z80{B: 0x01, C: 0xFF},
+			program:  []byte{0x01, 0xFF, 0x01},
+			input:    SM83{},
+			expected: SM83{B: 0x01, C: 0xFF},
 			cycles:   12,
 		},
 		{
 			name:     "LD DE, nn",
-			program:  []byte{0x11, 0x01, 0xFF},
-			input:    Z80{},
-			expected: Z80{D: 0x01, E: 0xFF},
+			program:  []byte{0x11, 0xFF, 0x01},
+			input:    SM83{},
+			expected: SM83{D: 0x01, E: 0xFF},
 			cycles:   12,
 		},
 		{
 			name:     "LD HL, nn",
-			program:  []byte{0x21, 0x01, 0xFF},
-			input:    Z80{},
-			expected: Z80{H: 0x01, L: 0xFF},
+			program:  []byte{0x21, 0xFF, 0x01},
+			input:    SM83{},
+			expected: SM83{H: 0x01, L: 0xFF},
 			cycles:   12,
 		},
 		{
 			name:     "LD SP, nn",
-			program:  []byte{0x31, 0x01, 0xFF},
-			input:    Z80{},
-			expected: Z80{SP: 0x01FF},
+			program:  []byte{0x31, 0xFF, 0x01},
+			input:    SM83{},
+			expected: SM83{SP: 0x01FF},
 			cycles:   12,
 		},
 		{
 			name:     "LD SP, HL",
 			program:  []byte{0xF9},
-			input:    Z80{H: 0xFF, L: 0xFE},
-			expected: Z80{H: 0xFF, L: 0xFE, SP: 0xFFFE},
+			input:    SM83{H: 0xFF, L: 0xFE},
+			expected: SM83{H: 0xFF, L: 0xFE, SP: 0xFFFE},
 			cycles:   8,
 		},
 		{
 			name:     "LDHL SP, n",
 			program:  []byte{0xF8, 0x03},
-			input:    Z80{SP: 0x01FE},
-			expected: Z80{F: 0b00100000, H: 0x02, L: 0x01, SP: 0x01FE},
+			input:    SM83{SP: 0x01FE},
+			expected: SM83{F: 0b00110000, H: 0x02, L: 0x01, SP: 0x01FE},
+			cycles:   12,
+		},
+		{
+			name:     "LDHL SP, n negative offset",
+			program:  []byte{0xF8, 0xFE},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{H: 0x00, L: 0xFE, SP: 0x0100},
+			cycles:   12,
+		},
+		{
+			name:     "LDHL SP, n negative offset low byte carry",
+			program:  []byte{0xF8, 0xFF},
+			input:    SM83{SP: 0xFFF8},
+			expected: SM83{F: 0b00110000, H: 0xFF, L: 0xF7, SP: 0xFFF8},
+			cycles:   12,
+		},
+		{
+			name:     "LDHL SP, n resets Z and N",
+			program:  []byte{0xF8, 0x00},
+			input:    SM83{F: 0b11000000, SP: 0x0000},
+			expected: SM83{SP: 0x0000},
 			cycles:   12,
 		},
 		{
 			name:     "LD (nn), SP",
-			program:  []byte{0x08, 0x00, 0x04, 0x01, 0xFF, 0xFF},
-			input:    Z80{SP: 0xAAAA},
-			expected: Z80{B: 0xAA, C: 0xAA, SP: 0xAAAA},
+			program:  []byte{0x08, 0x04, 0x00, 0x01, 0xFF, 0xFF},
+			input:    SM83{SP: 0xBEEF},
+			expected: SM83{B: 0xBE, C: 0xEF, SP: 0xBEEF},
 			cycles:   20 + 12,
 		},
 	}
@@ -0,0 +1,553 @@
+package cpu
+
+import "strings"
+
+// OpcodeInfo describes an SM83 instruction for tools such as disassemblers,
+// tracers and debuggers
+type OpcodeInfo struct {
+	Opcode      byte     `json:"opcode"`
+	Prefixed    bool     `json:"prefixed,omitempty"` // true for instructions prefixed by 0xCB
+	Illegal     bool     `json:"illegal,omitempty"`  // true for opcodes that lock up the CPU
+	Mnemonic    string   `json:"mnemonic"`
+	Operands    []string `json:"operands,omitempty"` // immediates are n, nn and e
+	Length      int      `json:"length"`             // bytes, including prefix and operands
+	Cycles      int      `json:"cycles"`             // T-cycles, when not taken for conditional instructions
+	CyclesTaken int      `json:"cycles_taken"`       // T-cycles when a conditional branch is taken
+	Flags       string   `json:"flags"`              // Z, N, H and C: the letter if affected, 0 or 1 if reset or set, - if unchanged
+}
+
+// Name returns the instruction in assembly syntax, e.g. "LD A, n"
+func (o OpcodeInfo) Name() string {
+	if len(o.Operands) == 0 {
+		return o.Mnemonic
+	}
+	return o.Mnemonic + " " + strings.Join(o.Operands, ", ")
+}
+
+// Conditional reports whether the instruction takes a different number of
+// cycles depending on a condition
+func (o OpcodeInfo) Conditional() bool {
+	return o.CyclesTaken != o.Cycles
+}
+
+// OpcodeTable describes every unprefixed opcode, indexed by the opcode byte.
+// STOP is 2 bytes long because the byte after it is skipped.
+var OpcodeTable = [256]OpcodeInfo{
+	0x00: {Opcode: 0x00, Mnemonic: "NOP", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x01: {Opcode: 0x01, Mnemonic: "LD", Operands: []string{"BC", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x02: {Opcode: 0x02, Mnemonic: "LD", Operands: []string{"(BC)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x03: {Opcode: 0x03, Mnemonic: "INC", Operands: []string{"BC"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x04: {Opcode: 0x04, Mnemonic: "INC", Operands: []string{"B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x05: {Opcode: 0x05, Mnemonic: "DEC", Operands: []string{"B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x06: {Opcode: 0x06, Mnemonic: "LD", Operands: []string{"B", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x07: {Opcode: 0x07, Mnemonic: "RLCA", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "000C"},
+	0x08: {Opcode: 0x08, Mnemonic: "LD", Operands: []string{"(nn)", "SP"}, Length: 3, Cycles: 20, CyclesTaken: 20, Flags: "----"},
+	0x09: {Opcode: 0x09, Mnemonic: "ADD", Operands: []string{"HL", "BC"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "-0HC"},
+	0x0A: {Opcode: 0x0A, Mnemonic: "LD", Operands: []string{"A", "(BC)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x0B: {Opcode: 0x0B, Mnemonic: "DEC", Operands: []string{"BC"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x0C: {Opcode: 0x0C, Mnemonic: "INC", Operands: []string{"C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x0D: {Opcode: 0x0D, Mnemonic: "DEC", Operands: []string{"C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x0E: {Opcode: 0x0E, Mnemonic: "LD", Operands: []string{"C", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x0F: {Opcode: 0x0F, Mnemonic: "RRCA", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "000C"},
+	0x10: {Opcode: 0x10, Mnemonic: "STOP", Length: 2, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x11: {Opcode: 0x11, Mnemonic: "LD", Operands: []string{"DE", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x12: {Opcode: 0x12, Mnemonic: "LD", Operands: []string{"(DE)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x13: {Opcode: 0x13, Mnemonic: "INC", Operands: []string{"DE"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x14: {Opcode: 0x14, Mnemonic: "INC", Operands: []string{"D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x15: {Opcode: 0x15, Mnemonic: "DEC", Operands: []string{"D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x16: {Opcode: 0x16, Mnemonic: "LD", Operands: []string{"D", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x17: {Opcode: 0x17, Mnemonic: "RLA", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "000C"},
+	0x18: {Opcode: 0x18, Mnemonic: "JR", Operands: []string{"e"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x19: {Opcode: 0x19, Mnemonic: "ADD", Operands: []string{"HL", "DE"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "-0HC"},
+	0x1A: {Opcode: 0x1A, Mnemonic: "LD", Operands: []string{"A", "(DE)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x1B: {Opcode: 0x1B, Mnemonic: "DEC", Operands: []string{"DE"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x1C: {Opcode: 0x1C, Mnemonic: "INC", Operands: []string{"E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x1D: {Opcode: 0x1D, Mnemonic: "DEC", Operands: []string{"E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x1E: {Opcode: 0x1E, Mnemonic: "LD", Operands: []string{"E", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x1F: {Opcode: 0x1F, Mnemonic: "RRA", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "000C"},
+	0x20: {Opcode: 0x20, Mnemonic: "JR", Operands: []string{"NZ", "e"}, Length: 2, Cycles: 8, CyclesTaken: 12, Flags: "----"},
+	0x21: {Opcode: 0x21, Mnemonic: "LD", Operands: []string{"HL", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x22: {Opcode: 0x22, Mnemonic: "LDI", Operands: []string{"(HL)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x23: {Opcode: 0x23, Mnemonic: "INC", Operands: []string{"HL"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x24: {Opcode: 0x24, Mnemonic: "INC", Operands: []string{"H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x25: {Opcode: 0x25, Mnemonic: "DEC", Operands: []string{"H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x26: {Opcode: 0x26, Mnemonic: "LD", Operands: []string{"H", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x27: {Opcode: 0x27, Mnemonic: "DAA", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z-0C"},
+	0x28: {Opcode: 0x28, Mnemonic: "JR", Operands: []string{"Z", "e"}, Length: 2, Cycles: 8, CyclesTaken: 12, Flags: "----"},
+	0x29: {Opcode: 0x29, Mnemonic: "ADD", Operands: []string{"HL", "HL"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "-0HC"},
+	0x2A: {Opcode: 0x2A, Mnemonic: "LDI", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x2B: {Opcode: 0x2B, Mnemonic: "DEC", Operands: []string{"HL"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x2C: {Opcode: 0x2C, Mnemonic: "INC", Operands: []string{"L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x2D: {Opcode: 0x2D, Mnemonic: "DEC", Operands: []string{"L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x2E: {Opcode: 0x2E, Mnemonic: "LD", Operands: []string{"L", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x2F: {Opcode: 0x2F, Mnemonic: "CPL", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "-11-"},
+	0x30: {Opcode: 0x30, Mnemonic: "JR", Operands: []string{"NC", "e"}, Length: 2, Cycles: 8, CyclesTaken: 12, Flags: "----"},
+	0x31: {Opcode: 0x31, Mnemonic: "LD", Operands: []string{"SP", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x32: {Opcode: 0x32, Mnemonic: "LDD", Operands: []string{"(HL)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x33: {Opcode: 0x33, Mnemonic: "INC", Operands: []string{"SP"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x34: {Opcode: 0x34, Mnemonic: "INC", Operands: []string{"(HL)"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "Z0H-"},
+	0x35: {Opcode: 0x35, Mnemonic: "DEC", Operands: []string{"(HL)"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "Z1H-"},
+	0x36: {Opcode: 0x36, Mnemonic: "LD", Operands: []string{"(HL)", "n"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0x37: {Opcode: 0x37, Mnemonic: "SCF", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "-001"},
+	0x38: {Opcode: 0x38, Mnemonic: "JR", Operands: []string{"C", "e"}, Length: 2, Cycles: 8, CyclesTaken: 12, Flags: "----"},
+	0x39: {Opcode: 0x39, Mnemonic: "ADD", Operands: []string{"HL", "SP"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "-0HC"},
+	0x3A: {Opcode: 0x3A, Mnemonic: "LDD", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x3B: {Opcode: 0x3B, Mnemonic: "DEC", Operands: []string{"SP"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x3C: {Opcode: 0x3C, Mnemonic: "INC", Operands: []string{"A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0H-"},
+	0x3D: {Opcode: 0x3D, Mnemonic: "DEC", Operands: []string{"A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1H-"},
+	0x3E: {Opcode: 0x3E, Mnemonic: "LD", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x3F: {Opcode: 0x3F, Mnemonic: "CCF", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "-00C"},
+	0x40: {Opcode: 0x40, Mnemonic: "LD", Operands: []string{"B", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x41: {Opcode: 0x41, Mnemonic: "LD", Operands: []string{"B", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x42: {Opcode: 0x42, Mnemonic: "LD", Operands: []string{"B", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x43: {Opcode: 0x43, Mnemonic: "LD", Operands: []string{"B", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x44: {Opcode: 0x44, Mnemonic: "LD", Operands: []string{"B", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x45: {Opcode: 0x45, Mnemonic: "LD", Operands: []string{"B", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x46: {Opcode: 0x46, Mnemonic: "LD", Operands: []string{"B", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x47: {Opcode: 0x47, Mnemonic: "LD", Operands: []string{"B", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x48: {Opcode: 0x48, Mnemonic: "LD", Operands: []string{"C", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x49: {Opcode: 0x49, Mnemonic: "LD", Operands: []string{"C", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x4A: {Opcode: 0x4A, Mnemonic: "LD", Operands: []string{"C", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x4B: {Opcode: 0x4B, Mnemonic: "LD", Operands: []string{"C", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x4C: {Opcode: 0x4C, Mnemonic: "LD", Operands: []string{"C", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x4D: {Opcode: 0x4D, Mnemonic: "LD", Operands: []string{"C", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x4E: {Opcode: 0x4E, Mnemonic: "LD", Operands: []string{"C", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x4F: {Opcode: 0x4F, Mnemonic: "LD", Operands: []string{"C", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x50: {Opcode: 0x50, Mnemonic: "LD", Operands: []string{"D", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x51: {Opcode: 0x51, Mnemonic: "LD", Operands: []string{"D", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x52: {Opcode: 0x52, Mnemonic: "LD", Operands: []string{"D", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x53: {Opcode: 0x53, Mnemonic: "LD", Operands: []string{"D", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x54: {Opcode: 0x54, Mnemonic: "LD", Operands: []string{"D", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x55: {Opcode: 0x55, Mnemonic: "LD", Operands: []string{"D", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x56: {Opcode: 0x56, Mnemonic: "LD", Operands: []string{"D", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x57: {Opcode: 0x57, Mnemonic: "LD", Operands: []string{"D", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x58: {Opcode: 0x58, Mnemonic: "LD", Operands: []string{"E", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x59: {Opcode: 0x59, Mnemonic: "LD", Operands: []string{"E", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x5A: {Opcode: 0x5A, Mnemonic: "LD", Operands: []string{"E", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x5B: {Opcode: 0x5B, Mnemonic: "LD", Operands: []string{"E", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x5C: {Opcode: 0x5C, Mnemonic: "LD", Operands: []string{"E", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x5D: {Opcode: 0x5D, Mnemonic: "LD", Operands: []string{"E", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x5E: {Opcode: 0x5E, Mnemonic: "LD", Operands: []string{"E", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x5F: {Opcode: 0x5F, Mnemonic: "LD", Operands: []string{"E", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x60: {Opcode: 0x60, Mnemonic: "LD", Operands: []string{"H", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x61: {Opcode: 0x61, Mnemonic: "LD", Operands: []string{"H", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x62: {Opcode: 0x62, Mnemonic: "LD", Operands: []string{"H", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x63: {Opcode: 0x63, Mnemonic: "LD", Operands: []string{"H", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x64: {Opcode: 0x64, Mnemonic: "LD", Operands: []string{"H", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x65: {Opcode: 0x65, Mnemonic: "LD", Operands: []string{"H", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x66: {Opcode: 0x66, Mnemonic: "LD", Operands: []string{"H", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x67: {Opcode: 0x67, Mnemonic: "LD", Operands: []string{"H", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x68: {Opcode: 0x68, Mnemonic: "LD", Operands: []string{"L", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x69: {Opcode: 0x69, Mnemonic: "LD", Operands: []string{"L", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x6A: {Opcode: 0x6A, Mnemonic: "LD", Operands: []string{"L", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x6B: {Opcode: 0x6B, Mnemonic: "LD", Operands: []string{"L", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x6C: {Opcode: 0x6C, Mnemonic: "LD", Operands: []string{"L", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x6D: {Opcode: 0x6D, Mnemonic: "LD", Operands: []string{"L", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x6E: {Opcode: 0x6E, Mnemonic: "LD", Operands: []string{"L", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x6F: {Opcode: 0x6F, Mnemonic: "LD", Operands: []string{"L", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x70: {Opcode: 0x70, Mnemonic: "LD", Operands: []string{"(HL)", "B"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x71: {Opcode: 0x71, Mnemonic: "LD", Operands: []string{"(HL)", "C"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x72: {Opcode: 0x72, Mnemonic: "LD", Operands: []string{"(HL)", "D"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x73: {Opcode: 0x73, Mnemonic: "LD", Operands: []string{"(HL)", "E"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x74: {Opcode: 0x74, Mnemonic: "LD", Operands: []string{"(HL)", "H"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x75: {Opcode: 0x75, Mnemonic: "LD", Operands: []string{"(HL)", "L"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x76: {Opcode: 0x76, Mnemonic: "HALT", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x77: {Opcode: 0x77, Mnemonic: "LD", Operands: []string{"(HL)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x78: {Opcode: 0x78, Mnemonic: "LD", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x79: {Opcode: 0x79, Mnemonic: "LD", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x7A: {Opcode: 0x7A, Mnemonic: "LD", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x7B: {Opcode: 0x7B, Mnemonic: "LD", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x7C: {Opcode: 0x7C, Mnemonic: "LD", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x7D: {Opcode: 0x7D, Mnemonic: "LD", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x7E: {Opcode: 0x7E, Mnemonic: "LD", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x7F: {Opcode: 0x7F, Mnemonic: "LD", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0x80: {Opcode: 0x80, Mnemonic: "ADD", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x81: {Opcode: 0x81, Mnemonic: "ADD", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x82: {Opcode: 0x82, Mnemonic: "ADD", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x83: {Opcode: 0x83, Mnemonic: "ADD", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x84: {Opcode: 0x84, Mnemonic: "ADD", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x85: {Opcode: 0x85, Mnemonic: "ADD", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x86: {Opcode: 0x86, Mnemonic: "ADD", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z0HC"},
+	0x87: {Opcode: 0x87, Mnemonic: "ADD", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x88: {Opcode: 0x88, Mnemonic: "ADC", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x89: {Opcode: 0x89, Mnemonic: "ADC", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x8A: {Opcode: 0x8A, Mnemonic: "ADC", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x8B: {Opcode: 0x8B, Mnemonic: "ADC", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x8C: {Opcode: 0x8C, Mnemonic: "ADC", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x8D: {Opcode: 0x8D, Mnemonic: "ADC", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x8E: {Opcode: 0x8E, Mnemonic: "ADC", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z0HC"},
+	0x8F: {Opcode: 0x8F, Mnemonic: "ADC", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z0HC"},
+	0x90: {Opcode: 0x90, Mnemonic: "SUB", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x91: {Opcode: 0x91, Mnemonic: "SUB", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x92: {Opcode: 0x92, Mnemonic: "SUB", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x93: {Opcode: 0x93, Mnemonic: "SUB", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x94: {Opcode: 0x94, Mnemonic: "SUB", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x95: {Opcode: 0x95, Mnemonic: "SUB", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x96: {Opcode: 0x96, Mnemonic: "SUB", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0x97: {Opcode: 0x97, Mnemonic: "SUB", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x98: {Opcode: 0x98, Mnemonic: "SBC", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x99: {Opcode: 0x99, Mnemonic: "SBC", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x9A: {Opcode: 0x9A, Mnemonic: "SBC", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x9B: {Opcode: 0x9B, Mnemonic: "SBC", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x9C: {Opcode: 0x9C, Mnemonic: "SBC", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x9D: {Opcode: 0x9D, Mnemonic: "SBC", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0x9E: {Opcode: 0x9E, Mnemonic: "SBC", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0x9F: {Opcode: 0x9F, Mnemonic: "SBC", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xA0: {Opcode: 0xA0, Mnemonic: "AND", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA1: {Opcode: 0xA1, Mnemonic: "AND", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA2: {Opcode: 0xA2, Mnemonic: "AND", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA3: {Opcode: 0xA3, Mnemonic: "AND", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA4: {Opcode: 0xA4, Mnemonic: "AND", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA5: {Opcode: 0xA5, Mnemonic: "AND", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA6: {Opcode: 0xA6, Mnemonic: "AND", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z010"},
+	0xA7: {Opcode: 0xA7, Mnemonic: "AND", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z010"},
+	0xA8: {Opcode: 0xA8, Mnemonic: "XOR", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xA9: {Opcode: 0xA9, Mnemonic: "XOR", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xAA: {Opcode: 0xAA, Mnemonic: "XOR", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xAB: {Opcode: 0xAB, Mnemonic: "XOR", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xAC: {Opcode: 0xAC, Mnemonic: "XOR", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xAD: {Opcode: 0xAD, Mnemonic: "XOR", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xAE: {Opcode: 0xAE, Mnemonic: "XOR", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0xAF: {Opcode: 0xAF, Mnemonic: "XOR", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB0: {Opcode: 0xB0, Mnemonic: "OR", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB1: {Opcode: 0xB1, Mnemonic: "OR", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB2: {Opcode: 0xB2, Mnemonic: "OR", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB3: {Opcode: 0xB3, Mnemonic: "OR", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB4: {Opcode: 0xB4, Mnemonic: "OR", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB5: {Opcode: 0xB5, Mnemonic: "OR", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB6: {Opcode: 0xB6, Mnemonic: "OR", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0xB7: {Opcode: 0xB7, Mnemonic: "OR", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z000"},
+	0xB8: {Opcode: 0xB8, Mnemonic: "CP", Operands: []string{"A", "B"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xB9: {Opcode: 0xB9, Mnemonic: "CP", Operands: []string{"A", "C"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xBA: {Opcode: 0xBA, Mnemonic: "CP", Operands: []string{"A", "D"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xBB: {Opcode: 0xBB, Mnemonic: "CP", Operands: []string{"A", "E"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xBC: {Opcode: 0xBC, Mnemonic: "CP", Operands: []string{"A", "H"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xBD: {Opcode: 0xBD, Mnemonic: "CP", Operands: []string{"A", "L"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xBE: {Opcode: 0xBE, Mnemonic: "CP", Operands: []string{"A", "(HL)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0xBF: {Opcode: 0xBF, Mnemonic: "CP", Operands: []string{"A", "A"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "Z1HC"},
+	0xC0: {Opcode: 0xC0, Mnemonic: "RET", Operands: []string{"NZ"}, Length: 1, Cycles: 8, CyclesTaken: 20, Flags: "----"},
+	0xC1: {Opcode: 0xC1, Mnemonic: "POP", Operands: []string{"BC"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0xC2: {Opcode: 0xC2, Mnemonic: "JP", Operands: []string{"NZ", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 16, Flags: "----"},
+	0xC3: {Opcode: 0xC3, Mnemonic: "JP", Operands: []string{"nn"}, Length: 3, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xC4: {Opcode: 0xC4, Mnemonic: "CALL", Operands: []string{"NZ", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 24, Flags: "----"},
+	0xC5: {Opcode: 0xC5, Mnemonic: "PUSH", Operands: []string{"BC"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xC6: {Opcode: 0xC6, Mnemonic: "ADD", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z0HC"},
+	0xC7: {Opcode: 0xC7, Mnemonic: "RST", Operands: []string{"00H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xC8: {Opcode: 0xC8, Mnemonic: "RET", Operands: []string{"Z"}, Length: 1, Cycles: 8, CyclesTaken: 20, Flags: "----"},
+	0xC9: {Opcode: 0xC9, Mnemonic: "RET", Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xCA: {Opcode: 0xCA, Mnemonic: "JP", Operands: []string{"Z", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 16, Flags: "----"},
+	0xCB: {Opcode: 0xCB, Mnemonic: "PREFIX", Operands: []string{"CB"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xCC: {Opcode: 0xCC, Mnemonic: "CALL", Operands: []string{"Z", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 24, Flags: "----"},
+	0xCD: {Opcode: 0xCD, Mnemonic: "CALL", Operands: []string{"nn"}, Length: 3, Cycles: 24, CyclesTaken: 24, Flags: "----"},
+	0xCE: {Opcode: 0xCE, Mnemonic: "ADC", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z0HC"},
+	0xCF: {Opcode: 0xCF, Mnemonic: "RST", Operands: []string{"08H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xD0: {Opcode: 0xD0, Mnemonic: "RET", Operands: []string{"NC"}, Length: 1, Cycles: 8, CyclesTaken: 20, Flags: "----"},
+	0xD1: {Opcode: 0xD1, Mnemonic: "POP", Operands: []string{"DE"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0xD2: {Opcode: 0xD2, Mnemonic: "JP", Operands: []string{"NC", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 16, Flags: "----"},
+	0xD3: {Opcode: 0xD3, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xD4: {Opcode: 0xD4, Mnemonic: "CALL", Operands: []string{"NC", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 24, Flags: "----"},
+	0xD5: {Opcode: 0xD5, Mnemonic: "PUSH", Operands: []string{"DE"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xD6: {Opcode: 0xD6, Mnemonic: "SUB", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0xD7: {Opcode: 0xD7, Mnemonic: "RST", Operands: []string{"10H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xD8: {Opcode: 0xD8, Mnemonic: "RET", Operands: []string{"C"}, Length: 1, Cycles: 8, CyclesTaken: 20, Flags: "----"},
+	0xD9: {Opcode: 0xD9, Mnemonic: "RETI", Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xDA: {Opcode: 0xDA, Mnemonic: "JP", Operands: []string{"C", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 16, Flags: "----"},
+	0xDB: {Opcode: 0xDB, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xDC: {Opcode: 0xDC, Mnemonic: "CALL", Operands: []string{"C", "nn"}, Length: 3, Cycles: 12, CyclesTaken: 24, Flags: "----"},
+	0xDD: {Opcode: 0xDD, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xDE: {Opcode: 0xDE, Mnemonic: "SBC", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0xDF: {Opcode: 0xDF, Mnemonic: "RST", Operands: []string{"18H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xE0: {Opcode: 0xE0, Mnemonic: "LDH", Operands: []string{"(n)", "A"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0xE1: {Opcode: 0xE1, Mnemonic: "POP", Operands: []string{"HL"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0xE2: {Opcode: 0xE2, Mnemonic: "LD", Operands: []string{"(C)", "A"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE3: {Opcode: 0xE3, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xE4: {Opcode: 0xE4, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xE5: {Opcode: 0xE5, Mnemonic: "PUSH", Operands: []string{"HL"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xE6: {Opcode: 0xE6, Mnemonic: "AND", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z010"},
+	0xE7: {Opcode: 0xE7, Mnemonic: "RST", Operands: []string{"20H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xE8: {Opcode: 0xE8, Mnemonic: "ADD", Operands: []string{"SP", "e"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "00HC"},
+	0xE9: {Opcode: 0xE9, Mnemonic: "JP", Operands: []string{"(HL)"}, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xEA: {Opcode: 0xEA, Mnemonic: "LD", Operands: []string{"(nn)", "A"}, Length: 3, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xEB: {Opcode: 0xEB, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xEC: {Opcode: 0xEC, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xED: {Opcode: 0xED, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xEE: {Opcode: 0xEE, Mnemonic: "XOR", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0xEF: {Opcode: 0xEF, Mnemonic: "RST", Operands: []string{"28H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xF0: {Opcode: 0xF0, Mnemonic: "LDH", Operands: []string{"A", "(n)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "----"},
+	0xF1: {Opcode: 0xF1, Mnemonic: "POP", Operands: []string{"AF"}, Length: 1, Cycles: 12, CyclesTaken: 12, Flags: "ZNHC"},
+	0xF2: {Opcode: 0xF2, Mnemonic: "LD", Operands: []string{"A", "(C)"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF3: {Opcode: 0xF3, Mnemonic: "DI", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xF4: {Opcode: 0xF4, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xF5: {Opcode: 0xF5, Mnemonic: "PUSH", Operands: []string{"AF"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xF6: {Opcode: 0xF6, Mnemonic: "OR", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0xF7: {Opcode: 0xF7, Mnemonic: "RST", Operands: []string{"30H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xF8: {Opcode: 0xF8, Mnemonic: "LD", Operands: []string{"HL", "SP+e"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "00HC"},
+	0xF9: {Opcode: 0xF9, Mnemonic: "LD", Operands: []string{"SP", "HL"}, Length: 1, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFA: {Opcode: 0xFA, Mnemonic: "LD", Operands: []string{"A", "(nn)"}, Length: 3, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xFB: {Opcode: 0xFB, Mnemonic: "EI", Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xFC: {Opcode: 0xFC, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xFD: {Opcode: 0xFD, Illegal: true, Length: 1, Cycles: 4, CyclesTaken: 4, Flags: "----"},
+	0xFE: {Opcode: 0xFE, Mnemonic: "CP", Operands: []string{"A", "n"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z1HC"},
+	0xFF: {Opcode: 0xFF, Mnemonic: "RST", Operands: []string{"38H"}, Length: 1, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+}
+
+// CBOpcodeTable describes every instruction prefixed by 0xCB, indexed by the
+// byte after the prefix
+var CBOpcodeTable = [256]OpcodeInfo{
+	0x00: {Opcode: 0x00, Prefixed: true, Mnemonic: "RLC", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x01: {Opcode: 0x01, Prefixed: true, Mnemonic: "RLC", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x02: {Opcode: 0x02, Prefixed: true, Mnemonic: "RLC", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x03: {Opcode: 0x03, Prefixed: true, Mnemonic: "RLC", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x04: {Opcode: 0x04, Prefixed: true, Mnemonic: "RLC", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x05: {Opcode: 0x05, Prefixed: true, Mnemonic: "RLC", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x06: {Opcode: 0x06, Prefixed: true, Mnemonic: "RLC", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x07: {Opcode: 0x07, Prefixed: true, Mnemonic: "RLC", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x08: {Opcode: 0x08, Prefixed: true, Mnemonic: "RRC", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x09: {Opcode: 0x09, Prefixed: true, Mnemonic: "RRC", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x0A: {Opcode: 0x0A, Prefixed: true, Mnemonic: "RRC", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x0B: {Opcode: 0x0B, Prefixed: true, Mnemonic: "RRC", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x0C: {Opcode: 0x0C, Prefixed: true, Mnemonic: "RRC", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x0D: {Opcode: 0x0D, Prefixed: true, Mnemonic: "RRC", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x0E: {Opcode: 0x0E, Prefixed: true, Mnemonic: "RRC", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x0F: {Opcode: 0x0F, Prefixed: true, Mnemonic: "RRC", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x10: {Opcode: 0x10, Prefixed: true, Mnemonic: "RL", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x11: {Opcode: 0x11, Prefixed: true, Mnemonic: "RL", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x12: {Opcode: 0x12, Prefixed: true, Mnemonic: "RL", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x13: {Opcode: 0x13, Prefixed: true, Mnemonic: "RL", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x14: {Opcode: 0x14, Prefixed: true, Mnemonic: "RL", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x15: {Opcode: 0x15, Prefixed: true, Mnemonic: "RL", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x16: {Opcode: 0x16, Prefixed: true, Mnemonic: "RL", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x17: {Opcode: 0x17, Prefixed: true, Mnemonic: "RL", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x18: {Opcode: 0x18, Prefixed: true, Mnemonic: "RR", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x19: {Opcode: 0x19, Prefixed: true, Mnemonic: "RR", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x1A: {Opcode: 0x1A, Prefixed: true, Mnemonic: "RR", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x1B: {Opcode: 0x1B, Prefixed: true, Mnemonic: "RR", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x1C: {Opcode: 0x1C, Prefixed: true, Mnemonic: "RR", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x1D: {Opcode: 0x1D, Prefixed: true, Mnemonic: "RR", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x1E: {Opcode: 0x1E, Prefixed: true, Mnemonic: "RR", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x1F: {Opcode: 0x1F, Prefixed: true, Mnemonic: "RR", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x20: {Opcode: 0x20, Prefixed: true, Mnemonic: "SLA", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x21: {Opcode: 0x21, Prefixed: true, Mnemonic: "SLA", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x22: {Opcode: 0x22, Prefixed: true, Mnemonic: "SLA", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x23: {Opcode: 0x23, Prefixed: true, Mnemonic: "SLA", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x24: {Opcode: 0x24, Prefixed: true, Mnemonic: "SLA", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x25: {Opcode: 0x25, Prefixed: true, Mnemonic: "SLA", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x26: {Opcode: 0x26, Prefixed: true, Mnemonic: "SLA", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x27: {Opcode: 0x27, Prefixed: true, Mnemonic: "SLA", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x28: {Opcode: 0x28, Prefixed: true, Mnemonic: "SRA", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x29: {Opcode: 0x29, Prefixed: true, Mnemonic: "SRA", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x2A: {Opcode: 0x2A, Prefixed: true, Mnemonic: "SRA", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x2B: {Opcode: 0x2B, Prefixed: true, Mnemonic: "SRA", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x2C: {Opcode: 0x2C, Prefixed: true, Mnemonic: "SRA", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x2D: {Opcode: 0x2D, Prefixed: true, Mnemonic: "SRA", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x2E: {Opcode: 0x2E, Prefixed: true, Mnemonic: "SRA", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x2F: {Opcode: 0x2F, Prefixed: true, Mnemonic: "SRA", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x30: {Opcode: 0x30, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x31: {Opcode: 0x31, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x32: {Opcode: 0x32, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x33: {Opcode: 0x33, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x34: {Opcode: 0x34, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x35: {Opcode: 0x35, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x36: {Opcode: 0x36, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z000"},
+	0x37: {Opcode: 0x37, Prefixed: true, Mnemonic: "SWAP", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z000"},
+	0x38: {Opcode: 0x38, Prefixed: true, Mnemonic: "SRL", Operands: []string{"B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x39: {Opcode: 0x39, Prefixed: true, Mnemonic: "SRL", Operands: []string{"C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x3A: {Opcode: 0x3A, Prefixed: true, Mnemonic: "SRL", Operands: []string{"D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x3B: {Opcode: 0x3B, Prefixed: true, Mnemonic: "SRL", Operands: []string{"E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x3C: {Opcode: 0x3C, Prefixed: true, Mnemonic: "SRL", Operands: []string{"H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x3D: {Opcode: 0x3D, Prefixed: true, Mnemonic: "SRL", Operands: []string{"L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x3E: {Opcode: 0x3E, Prefixed: true, Mnemonic: "SRL", Operands: []string{"(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "Z00C"},
+	0x3F: {Opcode: 0x3F, Prefixed: true, Mnemonic: "SRL", Operands: []string{"A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z00C"},
+	0x40: {Opcode: 0x40, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x41: {Opcode: 0x41, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x42: {Opcode: 0x42, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x43: {Opcode: 0x43, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x44: {Opcode: 0x44, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x45: {Opcode: 0x45, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x46: {Opcode: 0x46, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x47: {Opcode: 0x47, Prefixed: true, Mnemonic: "BIT", Operands: []string{"0", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x48: {Opcode: 0x48, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x49: {Opcode: 0x49, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x4A: {Opcode: 0x4A, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x4B: {Opcode: 0x4B, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x4C: {Opcode: 0x4C, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x4D: {Opcode: 0x4D, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x4E: {Opcode: 0x4E, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x4F: {Opcode: 0x4F, Prefixed: true, Mnemonic: "BIT", Operands: []string{"1", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x50: {Opcode: 0x50, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x51: {Opcode: 0x51, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x52: {Opcode: 0x52, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x53: {Opcode: 0x53, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x54: {Opcode: 0x54, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x55: {Opcode: 0x55, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x56: {Opcode: 0x56, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x57: {Opcode: 0x57, Prefixed: true, Mnemonic: "BIT", Operands: []string{"2", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x58: {Opcode: 0x58, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x59: {Opcode: 0x59, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x5A: {Opcode: 0x5A, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x5B: {Opcode: 0x5B, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x5C: {Opcode: 0x5C, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x5D: {Opcode: 0x5D, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x5E: {Opcode: 0x5E, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x5F: {Opcode: 0x5F, Prefixed: true, Mnemonic: "BIT", Operands: []string{"3", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x60: {Opcode: 0x60, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x61: {Opcode: 0x61, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x62: {Opcode: 0x62, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x63: {Opcode: 0x63, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x64: {Opcode: 0x64, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x65: {Opcode: 0x65, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x66: {Opcode: 0x66, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x67: {Opcode: 0x67, Prefixed: true, Mnemonic: "BIT", Operands: []string{"4", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x68: {Opcode: 0x68, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x69: {Opcode: 0x69, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x6A: {Opcode: 0x6A, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x6B: {Opcode: 0x6B, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x6C: {Opcode: 0x6C, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x6D: {Opcode: 0x6D, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x6E: {Opcode: 0x6E, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x6F: {Opcode: 0x6F, Prefixed: true, Mnemonic: "BIT", Operands: []string{"5", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x70: {Opcode: 0x70, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x71: {Opcode: 0x71, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x72: {Opcode: 0x72, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x73: {Opcode: 0x73, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x74: {Opcode: 0x74, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x75: {Opcode: 0x75, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x76: {Opcode: 0x76, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x77: {Opcode: 0x77, Prefixed: true, Mnemonic: "BIT", Operands: []string{"6", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x78: {Opcode: 0x78, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x79: {Opcode: 0x79, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x7A: {Opcode: 0x7A, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x7B: {Opcode: 0x7B, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x7C: {Opcode: 0x7C, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x7D: {Opcode: 0x7D, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x7E: {Opcode: 0x7E, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "(HL)"}, Length: 2, Cycles: 12, CyclesTaken: 12, Flags: "Z01-"},
+	0x7F: {Opcode: 0x7F, Prefixed: true, Mnemonic: "BIT", Operands: []string{"7", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "Z01-"},
+	0x80: {Opcode: 0x80, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x81: {Opcode: 0x81, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x82: {Opcode: 0x82, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x83: {Opcode: 0x83, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x84: {Opcode: 0x84, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x85: {Opcode: 0x85, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x86: {Opcode: 0x86, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0x87: {Opcode: 0x87, Prefixed: true, Mnemonic: "RES", Operands: []string{"0", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x88: {Opcode: 0x88, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x89: {Opcode: 0x89, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x8A: {Opcode: 0x8A, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x8B: {Opcode: 0x8B, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x8C: {Opcode: 0x8C, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x8D: {Opcode: 0x8D, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x8E: {Opcode: 0x8E, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0x8F: {Opcode: 0x8F, Prefixed: true, Mnemonic: "RES", Operands: []string{"1", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x90: {Opcode: 0x90, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x91: {Opcode: 0x91, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x92: {Opcode: 0x92, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x93: {Opcode: 0x93, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x94: {Opcode: 0x94, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x95: {Opcode: 0x95, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x96: {Opcode: 0x96, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0x97: {Opcode: 0x97, Prefixed: true, Mnemonic: "RES", Operands: []string{"2", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x98: {Opcode: 0x98, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x99: {Opcode: 0x99, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x9A: {Opcode: 0x9A, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x9B: {Opcode: 0x9B, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x9C: {Opcode: 0x9C, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x9D: {Opcode: 0x9D, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0x9E: {Opcode: 0x9E, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0x9F: {Opcode: 0x9F, Prefixed: true, Mnemonic: "RES", Operands: []string{"3", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA0: {Opcode: 0xA0, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA1: {Opcode: 0xA1, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA2: {Opcode: 0xA2, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA3: {Opcode: 0xA3, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA4: {Opcode: 0xA4, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA5: {Opcode: 0xA5, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA6: {Opcode: 0xA6, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xA7: {Opcode: 0xA7, Prefixed: true, Mnemonic: "RES", Operands: []string{"4", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA8: {Opcode: 0xA8, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xA9: {Opcode: 0xA9, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xAA: {Opcode: 0xAA, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xAB: {Opcode: 0xAB, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xAC: {Opcode: 0xAC, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xAD: {Opcode: 0xAD, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xAE: {Opcode: 0xAE, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xAF: {Opcode: 0xAF, Prefixed: true, Mnemonic: "RES", Operands: []string{"5", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB0: {Opcode: 0xB0, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB1: {Opcode: 0xB1, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB2: {Opcode: 0xB2, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB3: {Opcode: 0xB3, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB4: {Opcode: 0xB4, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB5: {Opcode: 0xB5, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB6: {Opcode: 0xB6, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xB7: {Opcode: 0xB7, Prefixed: true, Mnemonic: "RES", Operands: []string{"6", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB8: {Opcode: 0xB8, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xB9: {Opcode: 0xB9, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xBA: {Opcode: 0xBA, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xBB: {Opcode: 0xBB, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xBC: {Opcode: 0xBC, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xBD: {Opcode: 0xBD, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xBE: {Opcode: 0xBE, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xBF: {Opcode: 0xBF, Prefixed: true, Mnemonic: "RES", Operands: []string{"7", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC0: {Opcode: 0xC0, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC1: {Opcode: 0xC1, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC2: {Opcode: 0xC2, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC3: {Opcode: 0xC3, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC4: {Opcode: 0xC4, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC5: {Opcode: 0xC5, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC6: {Opcode: 0xC6, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xC7: {Opcode: 0xC7, Prefixed: true, Mnemonic: "SET", Operands: []string{"0", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC8: {Opcode: 0xC8, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xC9: {Opcode: 0xC9, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xCA: {Opcode: 0xCA, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xCB: {Opcode: 0xCB, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xCC: {Opcode: 0xCC, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xCD: {Opcode: 0xCD, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xCE: {Opcode: 0xCE, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xCF: {Opcode: 0xCF, Prefixed: true, Mnemonic: "SET", Operands: []string{"1", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD0: {Opcode: 0xD0, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD1: {Opcode: 0xD1, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD2: {Opcode: 0xD2, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD3: {Opcode: 0xD3, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD4: {Opcode: 0xD4, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD5: {Opcode: 0xD5, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD6: {Opcode: 0xD6, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xD7: {Opcode: 0xD7, Prefixed: true, Mnemonic: "SET", Operands: []string{"2", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD8: {Opcode: 0xD8, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xD9: {Opcode: 0xD9, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xDA: {Opcode: 0xDA, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xDB: {Opcode: 0xDB, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xDC: {Opcode: 0xDC, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xDD: {Opcode: 0xDD, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xDE: {Opcode: 0xDE, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xDF: {Opcode: 0xDF, Prefixed: true, Mnemonic: "SET", Operands: []string{"3", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE0: {Opcode: 0xE0, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE1: {Opcode: 0xE1, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE2: {Opcode: 0xE2, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE3: {Opcode: 0xE3, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE4: {Opcode: 0xE4, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE5: {Opcode: 0xE5, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE6: {Opcode: 0xE6, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xE7: {Opcode: 0xE7, Prefixed: true, Mnemonic: "SET", Operands: []string{"4", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE8: {Opcode: 0xE8, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xE9: {Opcode: 0xE9, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xEA: {Opcode: 0xEA, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xEB: {Opcode: 0xEB, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xEC: {Opcode: 0xEC, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xED: {Opcode: 0xED, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xEE: {Opcode: 0xEE, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xEF: {Opcode: 0xEF, Prefixed: true, Mnemonic: "SET", Operands: []string{"5", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF0: {Opcode: 0xF0, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF1: {Opcode: 0xF1, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF2: {Opcode: 0xF2, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF3: {Opcode: 0xF3, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF4: {Opcode: 0xF4, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF5: {Opcode: 0xF5, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF6: {Opcode: 0xF6, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xF7: {Opcode: 0xF7, Prefixed: true, Mnemonic: "SET", Operands: []string{"6", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF8: {Opcode: 0xF8, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "B"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xF9: {Opcode: 0xF9, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "C"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFA: {Opcode: 0xFA, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "D"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFB: {Opcode: 0xFB, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "E"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFC: {Opcode: 0xFC, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "H"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFD: {Opcode: 0xFD, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "L"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+	0xFE: {Opcode: 0xFE, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "(HL)"}, Length: 2, Cycles: 16, CyclesTaken: 16, Flags: "----"},
+	0xFF: {Opcode: 0xFF, Prefixed: true, Mnemonic: "SET", Operands: []string{"7", "A"}, Length: 2, Cycles: 8, CyclesTaken: 8, Flags: "----"},
+}
@@ -0,0 +1,154 @@
+package cpu
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+func TestOpcodeTableMatchesExecutor(t *testing.T) {
+	check := func(t *testing.T, info OpcodeInfo, inst opcode) {
+		if inst.exec == nil {
+			if !info.Illegal && info.Mnemonic != "PREFIX" {
+				t.Errorf("%02X: expected illegal or prefix, got %q", info.Opcode, info.Name())
+			}
+			return
+		}
+		if info.Illegal {
+			t.Errorf("%02X: implemented opcode marked as illegal", info.Opcode)
+		}
+		if info.Name() != inst.name {
+			t.Errorf("%02X: expected name %q, got %q", info.Opcode, inst.name, info.Name())
+		}
+		if info.Cycles != inst.cycles {
+			t.Errorf("%02X: expected cycles=%d, got %d", info.Opcode, inst.cycles, info.Cycles)
+		}
+		if len(info.Flags) != 4 {
+			t.Errorf("%02X: expected 4 flags, got %q", info.Opcode, info.Flags)
+		}
+	}
+
+	for op := range OpcodeTable {
+		if OpcodeTable[op].Opcode != byte(op) || OpcodeTable[op].Prefixed {
+			t.Errorf("%02X: wrong opcode %02X or prefix", op, OpcodeTable[op].Opcode)
+		}
+		if OpcodeTable[op].Illegal != illegal(byte(op)) {
+			t.Errorf("%02X: expected illegal=%v", op, illegal(byte(op)))
+		}
+		check(t, OpcodeTable[op], opcodes[op])
+	}
+
+	for op := range CBOpcodeTable {
+		if CBOpcodeTable[op].Opcode != byte(op) || !CBOpcodeTable[op].Prefixed {
+			t.Errorf("CB %02X: wrong opcode %02X or prefix", op, CBOpcodeTable[op].Opcode)
+		}
+		check(t, CBOpcodeTable[op], cbOpcodes[op])
+	}
+}
+
+// TestOpcodeTableLength checks that instructions which don't branch advance PC
+// by their length
+func TestOpcodeTableLength(t *testing.T) {
+	for op, info := range OpcodeTable {
+		if info.Illegal || info.Mnemonic == "PREFIX" {
+			continue
+		}
+		switch info.Mnemonic {
+		case "JP", "CALL", "RET", "RETI", "RST", "JR":
+			if !info.Conditional() {
+				continue
+			}
+		}
+
+		f, _ := conditionFlags(info.Name(), false)
+		z := &SM83{F: f, H: 0xC0, SP: 0xFFFE, ram: memory.NewMemory()}
+		z.LoadProgram([]byte{byte(op), 0x00, 0x00}, 0x0100)
+		if err := z.step(); err != nil {
+			t.Fatalf("%s: expected no error, got: %s", info.Name(), err)
+		}
+
+		if int(z.PC-0x0100) != info.Length {
+			t.Errorf("%s: expected length %d, got %d", info.Name(), info.Length, z.PC-0x0100)
+		}
+	}
+
+	for op, info := range CBOpcodeTable {
+		z := &SM83{H: 0xC0, ram: memory.NewMemory()}
+		z.LoadProgram([]byte{0xCB, byte(op)}, 0x0100)
+		if err := z.step(); err != nil {
+			t.Fatalf("%s: expected no error, got: %s", info.Name(), err)
+		}
+
+		if int(z.PC-0x0100) != info.Length {
+			t.Errorf("%s: expected length %d, got %d", info.Name(), info.Length, z.PC-0x0100)
+		}
+	}
+}
+
+// TestOpcodeTableFlags runs every instruction with random inputs and checks
+// that flags marked as unchanged, reset or set behave accordingly
+func TestOpcodeTableFlags(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	run := func(t *testing.T, info OpcodeInfo, program []byte) {
+		for i := 0; i < 64; i++ {
+			z := &SM83{
+				A: byte(r.Intn(256)), F: byte(r.Intn(16)) << 4,
+				B: byte(r.Intn(256)), C: byte(r.Intn(256)),
+				D: byte(r.Intn(256)), E: byte(r.Intn(256)),
+				H: 0xC0 | byte(r.Intn(32)), L: byte(r.Intn(256)),
+				SP: 0xD000 | uint16(r.Intn(0x1000)), ram: memory.NewMemory(),
+			}
+			z.LoadProgram(append(program, byte(r.Intn(256)), byte(r.Intn(256))), 0x0100)
+			z.ram.Write(z.HL(), byte(r.Intn(256)))
+			before := z.F
+
+			if err := z.step(); err != nil {
+				t.Fatalf("%s: expected no error, got: %s", info.Name(), err)
+			}
+
+			for bit, f := range info.Flags {
+				mask := byte(0x80) >> uint(bit)
+				switch f {
+				case '-':
+					if z.F&mask != before&mask {
+						t.Errorf("%s: expected flag %c unchanged, got %08b from %08b", info.Name(), "ZNHC"[bit], z.F, before)
+					}
+				case '0':
+					if z.F&mask != 0 {
+						t.Errorf("%s: expected flag %c reset, got %08b", info.Name(), "ZNHC"[bit], z.F)
+					}
+				case '1':
+					if z.F&mask == 0 {
+						t.Errorf("%s: expected flag %c set, got %08b", info.Name(), "ZNHC"[bit], z.F)
+					}
+				}
+			}
+		}
+	}
+
+	for op, info := range OpcodeTable {
+		if info.Illegal || info.Mnemonic == "PREFIX" {
+			continue
+		}
+		run(t, info, []byte{byte(op)})
+	}
+
+	for op, info := range CBOpcodeTable {
+		run(t, info, []byte{0xCB, byte(op)})
+	}
+}
+
+func TestOpcodeTableJSON(t *testing.T) {
+	b, err := json.Marshal(OpcodeTable[0x20])
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := `{"opcode":32,"mnemonic":"JR","operands":["NZ","e"],"length":2,"cycles":8,"cycles_taken":12,"flags":"----"}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
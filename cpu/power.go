@@ -0,0 +1,29 @@
+package cpu
+
+// halt suspends the CPU until an interrupt is pending. When IME is disabled
+// and an interrupt is already pending, the CPU does not halt and instead
+// fails to increment PC after reading the next opcode (the HALT bug).
+func (z *SM83) halt() {
+	if !z.ime && z.pendingInterrupts() != 0 {
+		z.haltBug = true
+		return
+	}
+	z.halted = true
+}
+
+// stop suspends the CPU until a joypad event is raised. The byte following
+// STOP is skipped.
+func (z *SM83) stop() {
+	z.PC++
+	z.stopped = true
+}
+
+// Halted returns true while the CPU is waiting for an interrupt
+func (z *SM83) Halted() bool {
+	return z.halted
+}
+
+// Stopped returns true while the CPU is waiting for a joypad event
+func (z *SM83) Stopped() bool {
+	return z.stopped
+}
@@ -0,0 +1,174 @@
+package cpu
+
+import (
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+func TestHALT(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0x76, 0x3C}, 0)
+
+	for i := 0; i < 3; i++ {
+		err := z.step()
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+	}
+
+	if !z.Halted() {
+		t.Errorf("expected CPU to be halted")
+	}
+	if z.PC != 0x0001 {
+		t.Errorf("expected PC %x, got %x", 0x0001, z.PC)
+	}
+	if z.A != 0 {
+		t.Errorf("expected A=%x, got %x", 0, z.A)
+	}
+	if z.cycles != 12 {
+		t.Errorf("expected cycles=%d, got %d", 12, z.cycles)
+	}
+}
+
+func TestHALTWakeUp(t *testing.T) {
+	tbl := []struct {
+		name       string
+		ime        bool
+		expectedPC uint16
+		expectedA  byte
+		expectedIF byte
+	}{
+		{
+			name:       "IME enabled dispatches the interrupt",
+			ime:        true,
+			expectedPC: 0x0040,
+			expectedA:  0x00,
+			expectedIF: 0x00,
+		},
+		{
+			name:       "IME disabled resumes after HALT",
+			ime:        false,
+			expectedPC: 0x0002,
+			expectedA:  0x01,
+			expectedIF: 0x01,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := &SM83{SP: 0xFFFE, ram: memory.NewMemory(), ime: tc.ime}
+			z.LoadProgram([]byte{0x76, 0x3C}, 0)
+			z.ram.Write(IE, 0x01)
+
+			z.step()
+			z.step()
+			if !z.Halted() {
+				t.Fatalf("expected CPU to be halted")
+			}
+
+			z.RequestInterrupt(VBlank)
+			z.step()
+
+			if z.Halted() {
+				t.Errorf("expected CPU to leave HALT")
+			}
+			if z.PC != tc.expectedPC {
+				t.Errorf("expected PC %x, got %x", tc.expectedPC, z.PC)
+			}
+			if z.A != tc.expectedA {
+				t.Errorf("expected A=%x, got %x", tc.expectedA, z.A)
+			}
+			if v := z.ram.Read(IF); v != tc.expectedIF {
+				t.Errorf("expected IF %b, got %b", tc.expectedIF, v)
+			}
+		})
+	}
+}
+
+func TestHALTBug(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0x76, 0x3C, 0x00}, 0)
+	z.ram.Write(IE, 0x01)
+	z.RequestInterrupt(VBlank)
+
+	z.step()
+	if z.Halted() {
+		t.Fatalf("expected CPU not to halt")
+	}
+
+	// the byte after HALT is executed twice
+	z.step()
+	if z.PC != 0x0001 {
+		t.Errorf("expected PC %x, got %x", 0x0001, z.PC)
+	}
+	z.step()
+	if z.PC != 0x0002 {
+		t.Errorf("expected PC %x, got %x", 0x0002, z.PC)
+	}
+	if z.A != 0x02 {
+		t.Errorf("expected A=%x, got %x", 0x02, z.A)
+	}
+}
+
+func TestHALTBugInterrupt(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory()}
+	z.LoadProgram([]byte{0xFB, 0x76, 0x0C}, 0x0100) // EI; HALT; INC C
+	z.ram.Write(0x0040, 0x04)                       // INC B
+	z.ram.Write(0x0041, 0xD9)                       // RETI
+	z.ram.Write(IE, 0x01)
+	z.RequestInterrupt(VBlank)
+
+	// EI, HALT with the HALT bug, the interrupt dispatch, INC B and RETI
+	for i := 0; i < 5; i++ {
+		if err := z.step(); err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+	}
+
+	// the handler runs once and returns to the HALT
+	if z.B != 0x01 {
+		t.Errorf("expected B=%x, got %x", 0x01, z.B)
+	}
+	if z.PC != 0x0101 {
+		t.Errorf("expected PC %x, got %x", 0x0101, z.PC)
+	}
+	if z.haltBug {
+		t.Errorf("expected the HALT bug to be cleared")
+	}
+}
+
+func TestSTOP(t *testing.T) {
+	z := &SM83{SP: 0xFFFE, ram: memory.NewMemory(), ime: true}
+	z.LoadProgram([]byte{0x10, 0x00, 0x3C}, 0)
+	z.ram.Write(IE, 0x01)
+
+	z.step()
+	if !z.Stopped() {
+		t.Fatalf("expected CPU to be stopped")
+	}
+	if z.PC != 0x0002 {
+		t.Errorf("expected PC %x, got %x", 0x0002, z.PC)
+	}
+
+	// only a joypad event leaves STOP
+	z.RequestInterrupt(VBlank)
+	z.step()
+	if !z.Stopped() {
+		t.Errorf("expected CPU to remain stopped")
+	}
+	if z.cycles != 8 {
+		t.Errorf("expected cycles=%d, got %d", 8, z.cycles)
+	}
+
+	z.ram.Write(IF, 0x00)
+	z.RequestInterrupt(Joypad)
+	if z.Stopped() {
+		t.Errorf("expected CPU to leave STOP")
+	}
+
+	z.step()
+	if z.A != 0x01 {
+		t.Errorf("expected A=%x, got %x", 0x01, z.A)
+	}
+}
@@ -1,34 +1,35 @@
 package cpu
 
-func (z *Z80) AF() uint16 {
+func (z *SM83) AF() uint16 {
 	return pair(z.A, z.F)
 }
 
-func (z *Z80) SetAF(v uint16) {
-	writePair(&z.A, &z.F, v)
+// SetAF sets A and F. The low bits of F are always 0.
+func (z *SM83) SetAF(v uint16) {
+	writePair(&z.A, &z.F, v&0xFFF0)
 }
 
-func (z *Z80) BC() uint16 {
+func (z *SM83) BC() uint16 {
 	return pair(z.B, z.C)
 }
 
-func (z *Z80) SetBC(v uint16) {
+func (z *SM83) SetBC(v uint16) {
 	writePair(&z.B, &z.C, v)
 }
 
-func (z *Z80) DE() uint16 {
+func (z *SM83) DE() uint16 {
 	return pair(z.D, z.E)
 }
 
-func (z *Z80) SetDE(v uint16) {
+func (z *SM83) SetDE(v uint16) {
 	writePair(&z.D, &z.E, v)
 }
 
-func (z *Z80) HL() uint16 {
+func (z *SM83) HL() uint16 {
 	return pair(z.H, z.L)
 }
 
-func (z *Z80) SetHL(v uint16) {
+func (z *SM83) SetHL(v uint16) {
 	writePair(&z.H, &z.L, v)
 }
 
@@ -0,0 +1,132 @@
+package cpu
+
+import (
+	"context"
+	"fmt"
+)
+
+// FrameCycles is the number of cycles in one Game Boy frame
+const FrameCycles = 70224
+
+// cancelCheckInterval is the number of steps between checks of the context,
+// which are too slow to do on every instruction
+const cancelCheckInterval = 1024
+
+// StopReason says why RunContext returned
+type StopReason int
+
+const (
+	StopError      StopReason = iota + 1 // an instruction failed
+	StopCanceled                         // the context was canceled or timed out
+	StopCycles                           // the cycle budget ran out
+	StopFrames                           // the frame count was reached
+	StopBreakpoint                       // PC reached a breakpoint
+	StopPredicate                        // the Until predicate returned true
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case StopError:
+		return "error"
+	case StopCanceled:
+		return "canceled"
+	case StopCycles:
+		return "cycle budget"
+	case StopFrames:
+		return "frame count"
+	case StopBreakpoint:
+		return "breakpoint"
+	case StopPredicate:
+		return "predicate"
+	}
+	return fmt.Sprintf("StopReason(%d)", int(r))
+}
+
+// RunOptions selects when RunContext stops. Zero values disable a condition.
+type RunOptions struct {
+	Cycles      int                // cycles to run
+	Frames      int                // frames to run, of FrameCycles each
+	Breakpoints []uint16           // stop before the instruction at any of these addresses
+	Until       func(z *SM83) bool // stop when it returns true, checked before every step
+}
+
+// RunResult describes a call to RunContext
+type RunResult struct {
+	Reason       StopReason
+	Cycles       int // cycles elapsed during the run
+	Instructions int // instructions executed, not counting low-power mode waits and interrupt dispatch
+	Frames       int // complete frames elapsed during the run
+}
+
+// RunContext runs the CPU until one of the conditions in opts is met, an
+// instruction fails or ctx is done. The cycle limit set by SetMaxCycles is
+// not used. A breakpoint at PC when RunContext is called does not stop it,
+// so that a run can be resumed from a breakpoint.
+//
+// The returned error is the instruction error for StopError and ctx.Err()
+// for StopCanceled.
+func (z *SM83) RunContext(ctx context.Context, opts RunOptions) (RunResult, error) {
+	var breakpoints map[uint16]bool
+	if len(opts.Breakpoints) > 0 {
+		breakpoints = make(map[uint16]bool, len(opts.Breakpoints))
+		for _, addr := range opts.Breakpoints {
+			breakpoints[addr] = true
+		}
+	}
+
+	start := z.cycles
+	var res RunResult
+	stop := func(reason StopReason, err error) (RunResult, error) {
+		res.Reason = reason
+		res.Cycles = z.cycles - start
+		res.Frames = res.Cycles / FrameCycles
+		return res, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return stop(StopCanceled, err)
+	}
+
+	done := ctx.Done()
+	resumePC := z.PC
+	for steps := 1; ; steps++ {
+		if done != nil && steps%cancelCheckInterval == 0 {
+			select {
+			case <-done:
+				return stop(StopCanceled, ctx.Err())
+			default:
+			}
+		}
+
+		elapsed := z.cycles - start
+		if opts.Cycles > 0 && elapsed >= opts.Cycles {
+			return stop(StopCycles, nil)
+		}
+		if opts.Frames > 0 && elapsed >= opts.Frames*FrameCycles {
+			return stop(StopFrames, nil)
+		}
+		if opts.Until != nil && opts.Until(z) {
+			return stop(StopPredicate, nil)
+		}
+
+		if z.wait() {
+			if z.fast {
+				z.flush()
+			}
+			continue
+		}
+
+		if breakpoints[z.PC] && (res.Instructions > 0 || z.PC != resumePC) {
+			return stop(StopBreakpoint, nil)
+		}
+
+		err := z.next()
+		if z.fast {
+			z.flush()
+		}
+		if err != nil {
+			return stop(StopError, err)
+		}
+		res.Instructions++
+	}
+}
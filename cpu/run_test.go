@@ -0,0 +1,167 @@
+package cpu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// counterProgram increments A forever: INC A; JR -3
+var counterProgram = []byte{0x3C, 0x18, 0xFD}
+
+func TestRunContext(t *testing.T) {
+	tbl := []struct {
+		name         string
+		opts         RunOptions
+		reason       StopReason
+		cycles       int
+		instructions int
+		a            byte
+	}{
+		{
+			name:         "cycle budget",
+			opts:         RunOptions{Cycles: 48},
+			reason:       StopCycles,
+			cycles:       48,
+			instructions: 6,
+			a:            3,
+		},
+		{
+			name:         "breakpoint",
+			opts:         RunOptions{Breakpoints: []uint16{0x0201}},
+			reason:       StopBreakpoint,
+			cycles:       4,
+			instructions: 1,
+			a:            1,
+		},
+		{
+			name:         "predicate",
+			opts:         RunOptions{Until: func(z *SM83) bool { return z.A == 5 }},
+			reason:       StopPredicate,
+			cycles:       5*4 + 4*12,
+			instructions: 9,
+			a:            5,
+		},
+		{
+			name:         "first condition met",
+			opts:         RunOptions{Cycles: 1000, Until: func(z *SM83) bool { return z.A == 2 }},
+			reason:       StopPredicate,
+			cycles:       2*4 + 12,
+			instructions: 3,
+			a:            2,
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z := NewSM83()
+			z.Reset()
+			z.LoadProgram(counterProgram, 0x0200)
+
+			res, err := z.RunContext(context.Background(), tc.opts)
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			expected := RunResult{Reason: tc.reason, Cycles: tc.cycles, Instructions: tc.instructions}
+			if res != expected {
+				t.Errorf("expected %+v, got %+v", expected, res)
+			}
+			if z.A != tc.a {
+				t.Errorf("expected A=%d, got %d", tc.a, z.A)
+			}
+		})
+	}
+}
+
+func TestRunContextResumeFromBreakpoint(t *testing.T) {
+	z := NewSM83()
+	z.Reset()
+	z.LoadProgram(counterProgram, 0x0200)
+	opts := RunOptions{Breakpoints: []uint16{0x0200}}
+
+	for i := 1; i <= 3; i++ {
+		res, err := z.RunContext(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+		if res.Reason != StopBreakpoint || res.Instructions != 2 {
+			t.Errorf("expected a breakpoint after 2 instructions, got %s after %d", res.Reason, res.Instructions)
+		}
+		if z.A != byte(i) {
+			t.Errorf("expected A=%d, got %d", i, z.A)
+		}
+	}
+}
+
+func TestRunContextFrames(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram(counterProgram, 0x0200)
+
+	res, err := z.RunContext(context.Background(), RunOptions{Frames: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if res.Reason != StopFrames || res.Frames != 2 {
+		t.Errorf("expected to stop after 2 frames, got %s after %d", res.Reason, res.Frames)
+	}
+	if res.Cycles < 2*FrameCycles || res.Cycles >= 2*FrameCycles+12 {
+		t.Errorf("expected %d cycles, got %d", 2*FrameCycles, res.Cycles)
+	}
+}
+
+func TestRunContextCanceled(t *testing.T) {
+	t.Run("before the run", func(t *testing.T) {
+		z := NewSM83()
+		z.LoadProgram(counterProgram, 0x0200)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		res, err := z.RunContext(ctx, RunOptions{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+		if res.Reason != StopCanceled || res.Cycles != 0 {
+			t.Errorf("expected to stop before running, got %+v", res)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		z := NewSM83()
+		z.LoadProgram(counterProgram, 0x0200)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		res, err := z.RunContext(ctx, RunOptions{})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+		if res.Reason != StopCanceled || res.Instructions == 0 {
+			t.Errorf("expected to stop after running, got %+v", res)
+		}
+	})
+}
+
+func TestRunContextError(t *testing.T) {
+	z := NewSM83()
+	z.LoadProgram([]byte{0x00, 0xD3}, 0x0200)
+
+	res, err := z.RunContext(context.Background(), RunOptions{})
+	var ie *IllegalOpcodeError
+	if !errors.As(err, &ie) {
+		t.Fatalf("expected IllegalOpcodeError, got: %v", err)
+	}
+	if res.Reason != StopError || res.Instructions != 1 {
+		t.Errorf("expected an error after 1 instruction, got %s after %d", res.Reason, res.Instructions)
+	}
+}
+
+func TestStopReasonString(t *testing.T) {
+	if s := StopBreakpoint.String(); s != "breakpoint" {
+		t.Errorf("expected %q, got %q", "breakpoint", s)
+	}
+	if s := StopReason(0).String(); s != "StopReason(0)" {
+		t.Errorf("expected %q, got %q", "StopReason(0)", s)
+	}
+}
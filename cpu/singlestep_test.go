@@ -0,0 +1,280 @@
+package cpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// The SingleStepTests sm83 test vectors (https://github.com/SingleStepTests/sm83)
+// are too big to keep in the repository. Download the v1 directory and point
+// SM83_TESTS at it, or copy it to testdata/sm83/v1.
+const singleStepDefaultDir = "testdata/sm83/v1"
+
+type singleStepState struct {
+	PC  uint16      `json:"pc"`
+	SP  uint16      `json:"sp"`
+	A   byte        `json:"a"`
+	B   byte        `json:"b"`
+	C   byte        `json:"c"`
+	D   byte        `json:"d"`
+	E   byte        `json:"e"`
+	F   byte        `json:"f"`
+	H   byte        `json:"h"`
+	L   byte        `json:"l"`
+	IME byte        `json:"ime"`
+	IE  *byte       `json:"ie"` // not in every final state
+	RAM [][2]uint16 `json:"ram"`
+}
+
+type singleStepCase struct {
+	Name    string          `json:"name"`
+	Initial singleStepState `json:"initial"`
+	Final   singleStepState `json:"final"`
+	// Cycles has one entry per M-cycle: address, data and r, w or - for
+	// the read, write and memory request pins. Internal cycles may be null.
+	Cycles [][]interface{} `json:"cycles"`
+}
+
+// busRecorder records memory accesses in the format of the test vectors
+type busRecorder struct {
+	BaseObserver
+	accesses []string
+}
+
+func (b *busRecorder) MemoryRead(addr uint16, val byte) {
+	b.accesses = append(b.accesses, fmt.Sprintf("r %04X %02X", addr, val))
+}
+
+func (b *busRecorder) MemoryWrite(addr uint16, val byte) {
+	b.accesses = append(b.accesses, fmt.Sprintf("w %04X %02X", addr, val))
+}
+
+// run executes the case and returns a description of the differences from the
+// expected final state, or an empty string if it passes
+func (tc *singleStepCase) run() string {
+	in := tc.Initial
+	z := &SM83{
+		PC: in.PC, SP: in.SP,
+		A: in.A, F: in.F, B: in.B, C: in.C, D: in.D, E: in.E, H: in.H, L: in.L,
+		ime: in.IME != 0,
+		ram: memory.NewMemory(),
+	}
+	for _, kv := range in.RAM {
+		z.ram.Write(kv[0], byte(kv[1]))
+	}
+	if in.IE != nil {
+		z.ram.Write(IE, *in.IE)
+	}
+
+	bus := &busRecorder{}
+	z.AddObserver(bus)
+
+	if err := z.step(); err != nil {
+		return err.Error()
+	}
+
+	var diff []string
+	out := tc.Final
+	regs := []struct {
+		name          string
+		expected, got uint16
+	}{
+		{"PC", out.PC, z.PC}, {"SP", out.SP, z.SP},
+		{"A", uint16(out.A), uint16(z.A)}, {"F", uint16(out.F), uint16(z.F)},
+		{"B", uint16(out.B), uint16(z.B)}, {"C", uint16(out.C), uint16(z.C)},
+		{"D", uint16(out.D), uint16(z.D)}, {"E", uint16(out.E), uint16(z.E)},
+		{"H", uint16(out.H), uint16(z.H)}, {"L", uint16(out.L), uint16(z.L)},
+		{"IME", uint16(out.IME), boolToUint16(z.ime)},
+	}
+	for _, r := range regs {
+		if r.expected != r.got {
+			diff = append(diff, fmt.Sprintf("%s: expected %X, got %X", r.name, r.expected, r.got))
+		}
+	}
+
+	for _, kv := range out.RAM {
+		if got := z.peek(kv[0]); got != byte(kv[1]) {
+			diff = append(diff, fmt.Sprintf("(%04X): expected %02X, got %02X", kv[0], kv[1], got))
+		}
+	}
+
+	if out.IE != nil {
+		if got := z.peek(IE); got != *out.IE {
+			diff = append(diff, fmt.Sprintf("IE: expected %02X, got %02X", *out.IE, got))
+		}
+	}
+
+	if expected := len(tc.Cycles) * 4; z.cycles != expected {
+		diff = append(diff, fmt.Sprintf("cycles: expected %d, got %d", expected, z.cycles))
+	}
+
+	var expected []string
+	for _, c := range tc.Cycles {
+		if len(c) != 3 {
+			continue
+		}
+		addr, _ := c[0].(float64)
+		val, _ := c[1].(float64)
+		pins, _ := c[2].(string)
+		switch {
+		case strings.HasPrefix(pins, "r"):
+			expected = append(expected, fmt.Sprintf("r %04X %02X", int(addr), int(val)))
+		case strings.HasPrefix(pins, "-w"):
+			expected = append(expected, fmt.Sprintf("w %04X %02X", int(addr), int(val)))
+		}
+	}
+	if strings.Join(expected, ", ") != strings.Join(bus.accesses, ", ") {
+		diff = append(diff, fmt.Sprintf("bus: expected [%s], got [%s]", strings.Join(expected, ", "), strings.Join(bus.accesses, ", ")))
+	}
+
+	return strings.Join(diff, "\n")
+}
+
+func boolToUint16(b bool) uint16 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// runSingleStepFile runs every case in a test vector file and returns the
+// number of cases and the failures
+func runSingleStepFile(path string) (total int, failures []string, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var cases []singleStepCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return 0, nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	for i := range cases {
+		if diff := cases[i].run(); diff != "" {
+			failures = append(failures, fmt.Sprintf("%s:\n%s", cases[i].Name, diff))
+		}
+	}
+	return len(cases), failures, nil
+}
+
+func TestSingleStep(t *testing.T) {
+	dir := os.Getenv("SM83_TESTS")
+	if dir == "" {
+		dir = singleStepDefaultDir
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) == 0 {
+		t.Skipf("no SingleStepTests files in %s, set SM83_TESTS to run them", dir)
+	}
+	sort.Strings(files)
+
+	var summary []string
+	for _, file := range files {
+		opcode := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(opcode, func(t *testing.T) {
+			total, failures, err := runSingleStepFile(file)
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			n := len(failures)
+			summary = append(summary, fmt.Sprintf("%s: %d/%d", opcode, total-n, total))
+			if n > 0 {
+				// the first few failures are usually enough to find the bug
+				if n > 3 {
+					failures = failures[:3]
+				}
+				t.Errorf("%d of %d cases failed, first failures:\n%s", n, total, strings.Join(failures, "\n"))
+			}
+		})
+	}
+
+	t.Logf("passed per opcode:\n%s", strings.Join(summary, "\n"))
+}
+
+// TestSingleStepRunner checks the runner itself with cases in the format of
+// the test vectors
+func TestSingleStepRunner(t *testing.T) {
+	sample := `[
+	{
+		"name": "00 0000",
+		"initial": {"pc": 19935, "sp": 59438, "a": 10, "b": 185, "c": 144, "d": 214, "e": 2, "f": 192, "h": 174, "l": 96, "ime": 0, "ie": 1, "ram": [[19935, 0]]},
+		"final": {"a": 10, "b": 185, "c": 144, "d": 214, "e": 2, "f": 192, "h": 174, "l": 96, "pc": 19936, "sp": 59438, "ime": 0, "ram": [[19935, 0]]},
+		"cycles": [[19935, 0, "r-m"]]
+	},
+	{
+		"name": "c5 0000",
+		"initial": {"pc": 49152, "sp": 53248, "a": 0, "b": 18, "c": 52, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "ime": 0, "ie": 0, "ram": [[49152, 197]]},
+		"final": {"a": 0, "b": 18, "c": 52, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "pc": 49153, "sp": 53246, "ime": 0, "ram": [[49152, 197], [53247, 18], [53246, 52]]},
+		"cycles": [[49152, 197, "r-m"], null, [53247, 18, "-wm"], [53246, 52, "-wm"]]
+	},
+	{
+		"name": "f1 0000",
+		"initial": {"pc": 49152, "sp": 53246, "a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "ime": 0, "ie": 0, "ram": [[49152, 241], [53246, 255], [53247, 18]]},
+		"final": {"a": 18, "b": 0, "c": 0, "d": 0, "e": 0, "f": 240, "h": 0, "l": 0, "pc": 49153, "sp": 53248, "ime": 0, "ram": [[49152, 241]]},
+		"cycles": [[49152, 241, "r-m"], [53246, 255, "r-m"], [53247, 18, "r-m"]]
+	},
+	{
+		"name": "3c failing",
+		"initial": {"pc": 0, "sp": 0, "a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "ime": 0, "ie": 0, "ram": [[0, 60]]},
+		"final": {"a": 2, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "pc": 1, "sp": 0, "ime": 0, "ram": [[0, 60]]},
+		"cycles": [[0, 60, "r-m"], null]
+	},
+	{
+		"name": "f3 0000",
+		"initial": {"pc": 0, "sp": 0, "a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "ime": 1, "ie": 1, "ram": [[0, 243]]},
+		"final": {"a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "pc": 1, "sp": 0, "ime": 0, "ie": 1, "ram": [[0, 243]]},
+		"cycles": [[0, 243, "r-m"]]
+	},
+	{
+		"name": "f3 failing",
+		"initial": {"pc": 0, "sp": 0, "a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "ime": 1, "ie": 0, "ram": [[0, 243]]},
+		"final": {"a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0, "h": 0, "l": 0, "pc": 1, "sp": 0, "ime": 1, "ie": 1, "ram": [[0, 243]]},
+		"cycles": [[0, 243, "r-m"]]
+	}
+]`
+
+	dir, err := ioutil.TempDir("", "sm83")
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sample.json")
+	if err := ioutil.WriteFile(path, []byte(sample), 0644); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	total, failures, err := runSingleStepFile(path)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+	if total != 6 {
+		t.Errorf("expected 6 cases, got %d", total)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %d: %v", len(failures), failures)
+	}
+
+	expected := [][]string{
+		{"3c failing", "A: expected 2, got 1", "cycles: expected 8, got 4"},
+		{"f3 failing", "IME: expected 1, got 0", "IE: expected 01, got 00"},
+	}
+	for i := range expected {
+		for _, e := range expected[i] {
+			if !strings.Contains(failures[i], e) {
+				t.Errorf("expected failure with %q, got:\n%s", e, failures[i])
+			}
+		}
+	}
+}
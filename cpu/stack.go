@@ -1,17 +1,20 @@
 package cpu
 
-func (z *Z80) push(v uint16) {
+// push stores v on the stack with the high byte at the higher address.
+// Decrementing SP before the first write takes an extra M-cycle.
+func (z *SM83) push(v uint16) {
 	hi, lo := split(v)
+	z.internal()
 	z.SP--
-	z.ram.Write(z.SP, lo)
+	z.write(z.SP, hi)
 	z.SP--
-	z.ram.Write(z.SP, hi)
+	z.write(z.SP, lo)
 }
 
-func (z *Z80) pop() uint16 {
-	hi := z.ram.Read(z.SP)
+func (z *SM83) pop() uint16 {
+	lo := z.read(z.SP)
 	z.SP++
-	lo := z.ram.Read(z.SP)
+	hi := z.read(z.SP)
 	z.SP++
 	return pair(hi, lo)
 }
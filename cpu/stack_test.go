@@ -12,29 +12,29 @@ func TestPUSH(t *testing.T) {
 		{
 			name:       "PUSH AF",
 			program:    []byte{0xF5},
-			input:      Z80{A: 0xCA, F: 0xC0, SP: 0x0100},
-			expected:   Z80{SP: 0x00FE, F: 0xC0},
+			input:      SM83{A: 0xCA, F: 0xC0, SP: 0x0100},
+			expected:   SM83{SP: 0x00FE, F: 0xC0},
 			expected16: 0xCAC0,
 		},
 		{
 			name:       "PUSH BC",
 			program:    []byte{0xC5},
-			input:      Z80{B: 0xDE, C: 0xAD, SP: 0x0100},
-			expected:   Z80{SP: 0x00FE},
+			input:      SM83{B: 0xDE, C: 0xAD, SP: 0x0100},
+			expected:   SM83{SP: 0x00FE},
 			expected16: 0xDEAD,
 		},
 		{
 			name:       "PUSH DE",
 			program:    []byte{0xD5},
-			input:      Z80{D: 0xBE, E: 0xEF, SP: 0x0100},
-			expected:   Z80{SP: 0x00FE},
+			input:      SM83{D: 0xBE, E: 0xEF, SP: 0x0100},
+			expected:   SM83{SP: 0x00FE},
 			expected16: 0xBEEF,
 		},
 		{
 			name:       "PUSH HL",
 			program:    []byte{0xE5},
-			input:      Z80{H: 0xF0, L: 0x0D, SP: 0x0100},
-			expected:   Z80{SP: 0x00FE},
+			input:      SM83{H: 0xF0, L: 0x0D, SP: 0x0100},
+			expected:   SM83{SP: 0x00FE},
 			expected16: 0xF00D,
 		},
 	}
@@ -54,8 +54,8 @@ func TestPUSH(t *testing.T) {
 				t.Errorf("expected SP %x, got %x", tc.expected.SP, tc.input.SP)
 			}
 
-			hi := m.Read(tc.input.SP)
-			lo := m.Read(tc.input.SP + 1)
+			lo := m.Read(tc.input.SP)
+			hi := m.Read(tc.input.SP + 1)
 
 			if value := pair(hi, lo); value != tc.expected16 {
 				t.Errorf("expected value %x, got %x", tc.expected16, value)
@@ -74,29 +74,36 @@ func TestPOP(t *testing.T) {
 			name:     "POP AF",
 			program:  []byte{0xF1},
 			input16:  0xCAC0,
-			input:    Z80{SP: 0x0100},
-			expected: Z80{A: 0xCA, F: 0xC0, SP: 0x0102},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{A: 0xCA, F: 0xC0, SP: 0x0102},
+		},
+		{
+			name:     "POP AF low bits of F",
+			program:  []byte{0xF1},
+			input16:  0x12FF,
+			input:    SM83{SP: 0x0100},
+			expected: SM83{A: 0x12, F: 0xF0, SP: 0x0102},
 		},
 		{
 			name:     "POP BC",
 			program:  []byte{0xC1},
 			input16:  0xDEAD,
-			input:    Z80{SP: 0x0100},
-			expected: Z80{B: 0xDE, C: 0xAD, SP: 0x0102},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{B: 0xDE, C: 0xAD, SP: 0x0102},
 		},
 		{
 			name:     "POP DE",
 			program:  []byte{0xD1},
 			input16:  0xBEEF,
-			input:    Z80{SP: 0x0100},
-			expected: Z80{D: 0xBE, E: 0xEF, SP: 0x0102},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{D: 0xBE, E: 0xEF, SP: 0x0102},
 		},
 		{
 			name:     "POP HL",
 			program:  []byte{0xE1},
 			input16:  0xF00D,
-			input:    Z80{SP: 0x0100},
-			expected: Z80{H: 0xF0, L: 0x0D, SP: 0x0102},
+			input:    SM83{SP: 0x0100},
+			expected: SM83{H: 0xF0, L: 0x0D, SP: 0x0102},
 		},
 	}
 
@@ -108,8 +115,8 @@ func TestPOP(t *testing.T) {
 
 			hi := byte(tc.input16 / 0x100)
 			lo := byte(tc.input16 % 0x100)
-			m.Write(tc.input.SP, hi)
-			m.Write(tc.input.SP+1, lo)
+			m.Write(tc.input.SP, lo)
+			m.Write(tc.input.SP+1, hi)
 
 			err := tc.input.step()
 			if err != nil {
@@ -0,0 +1,203 @@
+package cpu
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SetTrace writes the CPU state to w before every instruction, in the format
+// used by Gameboy Doctor. A nil w disables tracing. While tracing, Run steps
+// one instruction at a time instead of running whole cached blocks, so every
+// instruction is seen. Instructions still come from the block cache if it's
+// enabled.
+func (z *SM83) SetTrace(w io.Writer) {
+	z.trace = w
+	z.traceFrom, z.traceTo = 0x0000, 0xFFFF
+}
+
+// SetTraceRange limits tracing to instructions with from <= PC <= to
+func (z *SM83) SetTraceRange(from, to uint16) {
+	z.traceFrom, z.traceTo = from, to
+}
+
+func (z *SM83) traceState() error {
+	if z.PC < z.traceFrom || z.PC > z.traceTo {
+		return nil
+	}
+
+	s := TraceState{
+		A: z.A, F: z.F, B: z.B, C: z.C, D: z.D, E: z.E, H: z.H, L: z.L,
+		SP: z.SP, PC: z.PC,
+	}
+	for i := range s.PCMem {
+		s.PCMem[i] = z.peek(z.PC + uint16(i))
+	}
+
+	_, err := fmt.Fprintln(z.trace, s)
+	return err
+}
+
+// TraceState is one line of a Gameboy Doctor log: the registers and the four
+// bytes at PC before an instruction runs
+type TraceState struct {
+	A, F, B, C, D, E, H, L byte
+	SP, PC                 uint16
+	PCMem                  [4]byte
+}
+
+func (s TraceState) String() string {
+	return fmt.Sprintf("A:%02X F:%02X B:%02X C:%02X D:%02X E:%02X H:%02X L:%02X SP:%04X PC:%04X PCMEM:%02X,%02X,%02X,%02X",
+		s.A, s.F, s.B, s.C, s.D, s.E, s.H, s.L, s.SP, s.PC, s.PCMem[0], s.PCMem[1], s.PCMem[2], s.PCMem[3])
+}
+
+// ParseTraceLine parses one line of a Gameboy Doctor log
+func ParseTraceLine(line string) (TraceState, error) {
+	var s TraceState
+	fields := strings.Fields(line)
+	if len(fields) != 11 {
+		return s, fmt.Errorf("invalid trace line: %q", line)
+	}
+
+	regs := []*byte{&s.A, &s.F, &s.B, &s.C, &s.D, &s.E, &s.H, &s.L}
+	names := []string{"A", "F", "B", "C", "D", "E", "H", "L", "SP", "PC", "PCMEM"}
+	for i, f := range fields {
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 || kv[0] != names[i] {
+			return s, fmt.Errorf("invalid trace line: expected %s, got %q", names[i], f)
+		}
+
+		switch {
+		case i < len(regs):
+			v, err := strconv.ParseUint(kv[1], 16, 8)
+			if err != nil {
+				return s, fmt.Errorf("invalid trace line: %s", err)
+			}
+			*regs[i] = byte(v)
+		case kv[0] == "PCMEM":
+			mem := strings.Split(kv[1], ",")
+			if len(mem) != len(s.PCMem) {
+				return s, fmt.Errorf("invalid trace line: expected %d bytes in PCMEM, got %q", len(s.PCMem), kv[1])
+			}
+			for j, m := range mem {
+				v, err := strconv.ParseUint(m, 16, 8)
+				if err != nil {
+					return s, fmt.Errorf("invalid trace line: %s", err)
+				}
+				s.PCMem[j] = byte(v)
+			}
+		default:
+			v, err := strconv.ParseUint(kv[1], 16, 16)
+			if err != nil {
+				return s, fmt.Errorf("invalid trace line: %s", err)
+			}
+			if kv[0] == "SP" {
+				s.SP = uint16(v)
+			} else {
+				s.PC = uint16(v)
+			}
+		}
+	}
+	return s, nil
+}
+
+// TraceDivergence describes the first line where two traces differ
+type TraceDivergence struct {
+	Line     int         // 1-based line number
+	Previous *TraceState // state before the instruction that diverged, nil on the first line
+	Expected *TraceState // nil when the reference trace ended first
+	Actual   *TraceState // nil when the actual trace ended first
+}
+
+// String returns a report with the differences between the expected and
+// actual states
+func (d *TraceDivergence) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "line %d", d.Line)
+	if d.Previous != nil {
+		fmt.Fprintf(&b, ": after instruction at PC:%04X (PCMEM:%02X,%02X,%02X,%02X)",
+			d.Previous.PC, d.Previous.PCMem[0], d.Previous.PCMem[1], d.Previous.PCMem[2], d.Previous.PCMem[3])
+	}
+	b.WriteString("\n")
+
+	switch {
+	case d.Expected == nil:
+		fmt.Fprintf(&b, "  reference trace ended, got %s\n", d.Actual)
+		return b.String()
+	case d.Actual == nil:
+		fmt.Fprintf(&b, "  trace ended, expected %s\n", d.Expected)
+		return b.String()
+	}
+
+	e, a := d.Expected, d.Actual
+	regs := []struct {
+		name string
+		e, a byte
+	}{
+		{"A", e.A, a.A}, {"F", e.F, a.F}, {"B", e.B, a.B}, {"C", e.C, a.C},
+		{"D", e.D, a.D}, {"E", e.E, a.E}, {"H", e.H, a.H}, {"L", e.L, a.L},
+	}
+	for _, r := range regs {
+		switch {
+		case r.e == r.a:
+		case r.name == "F":
+			fmt.Fprintf(&b, "  F: expected %02X (%s), got %02X (%s)\n", r.e, FlagString(r.e), r.a, FlagString(r.a))
+		default:
+			fmt.Fprintf(&b, "  %s: expected %02X, got %02X\n", r.name, r.e, r.a)
+		}
+	}
+	if e.SP != a.SP {
+		fmt.Fprintf(&b, "  SP: expected %04X, got %04X\n", e.SP, a.SP)
+	}
+	if e.PC != a.PC {
+		fmt.Fprintf(&b, "  PC: expected %04X, got %04X\n", e.PC, a.PC)
+	}
+	for i := range e.PCMem {
+		if e.PCMem[i] != a.PCMem[i] {
+			fmt.Fprintf(&b, "  PCMEM[%d]: expected %02X, got %02X\n", i, e.PCMem[i], a.PCMem[i])
+		}
+	}
+	return b.String()
+}
+
+// CompareTraces reads two Gameboy Doctor logs and returns the first line
+// where they differ, or nil if they are the same
+func CompareTraces(reference, actual io.Reader) (*TraceDivergence, error) {
+	ref, act := bufio.NewScanner(reference), bufio.NewScanner(actual)
+
+	var previous *TraceState
+	for line := 1; ; line++ {
+		refOK, actOK := ref.Scan(), act.Scan()
+		if !refOK && !actOK {
+			break
+		}
+
+		d := &TraceDivergence{Line: line, Previous: previous}
+		if refOK {
+			s, err := ParseTraceLine(ref.Text())
+			if err != nil {
+				return nil, fmt.Errorf("reference line %d: %s", line, err)
+			}
+			d.Expected = &s
+		}
+		if actOK {
+			s, err := ParseTraceLine(act.Text())
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err)
+			}
+			d.Actual = &s
+		}
+
+		if d.Expected == nil || d.Actual == nil || *d.Expected != *d.Actual {
+			return d, nil
+		}
+		previous = d.Actual
+	}
+
+	if err := ref.Err(); err != nil {
+		return nil, err
+	}
+	return nil, act.Err()
+}
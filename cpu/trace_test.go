@@ -0,0 +1,168 @@
+package cpu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+// traceProgram is INC A; INC B; JR -4 at 0x0100
+var traceProgram = []byte{0x3C, 0x04, 0x18, 0xFC}
+
+func TestTrace(t *testing.T) {
+	for _, cached := range []bool{false, true} {
+		var buf bytes.Buffer
+		z := NewSM83()
+		z.SetBlockCache(cached)
+		z.LoadProgram(traceProgram, 0x0100)
+		z.SetTrace(&buf)
+		z.SetMaxCycles(32)
+
+		if err := z.Run(); err != nil {
+			t.Fatalf("expected no error, got: %s", err)
+		}
+
+		expected := `A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+A:02 F:10 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:04,18,FC,00
+A:02 F:10 B:01 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0102 PCMEM:18,FC,00,00
+A:02 F:10 B:01 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+A:03 F:10 B:01 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:04,18,FC,00
+A:03 F:10 B:02 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0102 PCMEM:18,FC,00,00
+`
+		if buf.String() != expected {
+			t.Errorf("cached=%v: expected:\n%s\ngot:\n%s", cached, expected, buf.String())
+		}
+	}
+}
+
+func TestTraceRange(t *testing.T) {
+	var buf bytes.Buffer
+	z := &SM83{ram: memory.NewMemory()}
+	z.LoadProgram(traceProgram, 0x0100)
+	z.SetTrace(&buf)
+	z.SetTraceRange(0x0102, 0x0102)
+	z.SetMaxCycles(64)
+
+	if err := z.Run(); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	for _, l := range lines {
+		if !strings.Contains(l, "PC:0102") {
+			t.Errorf("expected only PC:0102, got %s", l)
+		}
+	}
+}
+
+func TestParseTraceLine(t *testing.T) {
+	line := "A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02"
+	s, err := ParseTraceLine(line)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := TraceState{A: 0x01, F: 0xB0, C: 0x13, E: 0xD8, H: 0x01, L: 0x4D, SP: 0xFFFE, PC: 0x0100, PCMem: [4]byte{0x00, 0xC3, 0x13, 0x02}}
+	if s != expected {
+		t.Errorf("expected %+v, got %+v", expected, s)
+	}
+	if s.String() != line {
+		t.Errorf("expected %q, got %q", line, s.String())
+	}
+
+	for _, bad := range []string{
+		"",
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100",
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 X:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02",
+		"A:G1 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02",
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13",
+	} {
+		if _, err := ParseTraceLine(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestCompareTraces(t *testing.T) {
+	reference := `A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+A:02 F:10 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:04,18,FC,00
+A:02 F:10 B:01 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0102 PCMEM:18,FC,00,00
+`
+
+	tbl := []struct {
+		name     string
+		actual   string
+		line     int
+		expected []string
+	}{
+		{
+			name:   "same",
+			actual: reference,
+		},
+		{
+			name: "register and flags",
+			actual: `A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+A:03 F:30 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:04,18,FC,00
+`,
+			line:     2,
+			expected: []string{"after instruction at PC:0100", "A: expected 02, got 03", "F: expected 10 (---C), got 30 (--HC)"},
+		},
+		{
+			name: "memory",
+			actual: `A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+A:02 F:10 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:04,18,FD,00
+`,
+			line:     2,
+			expected: []string{"PCMEM[2]: expected FC, got FD"},
+		},
+		{
+			name: "ended early",
+			actual: `A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:3C,04,18,FC
+`,
+			line:     2,
+			expected: []string{"trace ended, expected A:02"},
+		},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := CompareTraces(strings.NewReader(reference), strings.NewReader(tc.actual))
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			if tc.line == 0 {
+				if d != nil {
+					t.Errorf("expected no divergence, got:\n%s", d)
+				}
+				return
+			}
+
+			if d == nil {
+				t.Fatalf("expected a divergence")
+			}
+			if d.Line != tc.line {
+				t.Errorf("expected line %d, got %d", tc.line, d.Line)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(d.String(), e) {
+					t.Errorf("expected report with %q, got:\n%s", e, d)
+				}
+			}
+		})
+	}
+}
+
+func TestFlagString(t *testing.T) {
+	tbl := map[byte]string{0x00: "----", 0xF0: "ZNHC", 0xA0: "Z-H-", 0x10: "---C", 0x0F: "----"}
+	for f, expected := range tbl {
+		if got := FlagString(f); got != expected {
+			t.Errorf("expected %s for %02X, got %s", expected, f, got)
+		}
+	}
+}
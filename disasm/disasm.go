@@ -0,0 +1,112 @@
+// Package disasm decodes SM83 machine code into plain listings, RGBDS
+// compatible source or JSON
+package disasm
+
+import (
+	"github.com/danicat/gogoboy/cpu"
+	"github.com/danicat/gogoboy/memory"
+)
+
+// Instruction is a decoded instruction
+type Instruction struct {
+	Address uint16
+	Bytes   []byte
+	Info    cpu.OpcodeInfo // the CB table entry for prefixed instructions
+
+	// Data is true when the bytes are not a valid instruction, either because
+	// the opcode is illegal or the code ends in the middle of an instruction
+	Data bool
+
+	// Target is the address a jump, call or restart goes to, resolved from
+	// relative offsets. HasTarget is false for other instructions.
+	Target    uint16
+	HasTarget bool
+}
+
+// Disassemble decodes code as if it were loaded at origin
+func Disassemble(code []byte, origin uint16) []Instruction {
+	read := func(offset int) (byte, bool) {
+		if offset >= len(code) {
+			return 0, false
+		}
+		return code[offset], true
+	}
+	return decode(read, origin, len(code))
+}
+
+// DisassembleMemory decodes length bytes of m starting at start
+func DisassembleMemory(m *memory.Memory, start uint16, length int) []Instruction {
+	read := func(offset int) (byte, bool) {
+		if offset >= length {
+			return 0, false
+		}
+		return m.Peek(start + uint16(offset)), true
+	}
+	return decode(read, start, length)
+}
+
+func decode(read func(offset int) (byte, bool), origin uint16, length int) []Instruction {
+	var insts []Instruction
+	for offset := 0; offset < length; {
+		inst := decodeAt(read, offset)
+		inst.Address = origin + uint16(offset)
+		resolve(&inst)
+		insts = append(insts, inst)
+		offset += len(inst.Bytes)
+	}
+	return insts
+}
+
+func decodeAt(read func(offset int) (byte, bool), offset int) Instruction {
+	op, _ := read(offset)
+	info := cpu.OpcodeTable[op]
+	if op == 0xCB {
+		next, ok := read(offset + 1)
+		if !ok {
+			return Instruction{Bytes: []byte{op}, Data: true}
+		}
+		info = cpu.CBOpcodeTable[next]
+	}
+
+	if info.Illegal {
+		return Instruction{Bytes: []byte{op}, Data: true}
+	}
+
+	b := make([]byte, info.Length)
+	for i := range b {
+		v, ok := read(offset + i)
+		if !ok {
+			// list the opcode as data and carry on from the next byte
+			return Instruction{Bytes: []byte{op}, Data: true}
+		}
+		b[i] = v
+	}
+
+	return Instruction{Bytes: b, Info: info}
+}
+
+// resolve fills in the target of jumps, calls and restarts
+func resolve(inst *Instruction) {
+	if inst.Data {
+		return
+	}
+
+	switch inst.Info.Mnemonic {
+	case "JR":
+		inst.Target = inst.Address + uint16(len(inst.Bytes)) + uint16(int8(inst.Bytes[1]))
+		inst.HasTarget = true
+	case "JP", "CALL":
+		if len(inst.Bytes) == 3 {
+			inst.Target = word(inst.Bytes)
+			inst.HasTarget = true
+		}
+	case "RST":
+		inst.Target = uint16(inst.Bytes[0] & 0x38)
+		inst.HasTarget = true
+	}
+}
+
+// word returns the little-endian 16-bit operand of a 3 byte instruction
+func word(b []byte) uint16 {
+	return uint16(b[2])<<8 | uint16(b[1])
+}
@@ -0,0 +1,181 @@
+package disasm
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+func TestDisassemble(t *testing.T) {
+	tbl := []struct {
+		name      string
+		code      []byte
+		plain     string
+		rgbds     string
+		target    uint16
+		hasTarget bool
+	}{
+		{"NOP", []byte{0x00}, "NOP", "nop", 0, false},
+		{"LD A, n", []byte{0x3E, 0x12}, "LD A, $12", "ld a, $12", 0, false},
+		{"LD (BC), A", []byte{0x02}, "LD (BC), A", "ld [bc], a", 0, false},
+		{"LD (nn), SP", []byte{0x08, 0x34, 0xC0}, "LD ($C034), SP", "ld [$C034], sp", 0, false},
+		{"LDH (n), A", []byte{0xE0, 0x44}, "LDH ($44), A", "ldh [$FF44], a", 0, false},
+		{"LD (C), A", []byte{0xE2}, "LD (C), A", "ldh [c], a", 0, false},
+		{"LDI (HL), A", []byte{0x22}, "LDI (HL), A", "ld [hl+], a", 0, false},
+		{"LDD A, (HL)", []byte{0x3A}, "LDD A, (HL)", "ld a, [hl-]", 0, false},
+		{"LD HL, SP+e", []byte{0xF8, 0xFE}, "LD HL, SP-2", "ld hl, sp-2", 0, false},
+		{"ADD SP, e", []byte{0xE8, 0x05}, "ADD SP, 5", "add sp, 5", 0, false},
+		{"JR NZ, e", []byte{0x20, 0xFE}, "JR NZ, $0100", "jr nz, $0100", 0x0100, true},
+		{"JR e", []byte{0x18, 0x10}, "JR $0112", "jr $0112", 0x0112, true},
+		{"JP nn", []byte{0xC3, 0x50, 0x01}, "JP $0150", "jp $0150", 0x0150, true},
+		{"JP (HL)", []byte{0xE9}, "JP (HL)", "jp hl", 0, false},
+		{"CALL C, nn", []byte{0xDC, 0x00, 0x40}, "CALL C, $4000", "call c, $4000", 0x4000, true},
+		{"RST 38H", []byte{0xFF}, "RST $0038", "rst $0038", 0x0038, true},
+		{"BIT 7, (HL)", []byte{0xCB, 0x7E}, "BIT 7, (HL)", "bit 7, [hl]", 0, false},
+		{"illegal", []byte{0xD3}, "DB $D3", "db $D3", 0, false},
+		{"truncated", []byte{0xC3, 0x50}, "DB $C3", "db $C3", 0, false},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			insts := Disassemble(tc.code, 0x0100)
+			if len(insts) == 0 {
+				t.Fatalf("expected instructions, got none")
+			}
+
+			inst := insts[0]
+			if inst.Address != 0x0100 {
+				t.Errorf("expected address %04X, got %04X", 0x0100, inst.Address)
+			}
+			if inst.String() != tc.plain {
+				t.Errorf("expected %q, got %q", tc.plain, inst.String())
+			}
+			if inst.rgbds(nil) != tc.rgbds {
+				t.Errorf("expected %q, got %q", tc.rgbds, inst.rgbds(nil))
+			}
+			if inst.HasTarget != tc.hasTarget || inst.Target != tc.target {
+				t.Errorf("expected target %04X (%v), got %04X (%v)", tc.target, tc.hasTarget, inst.Target, inst.HasTarget)
+			}
+		})
+	}
+}
+
+func TestDisassembleTruncated(t *testing.T) {
+	insts := Disassemble([]byte{0x00, 0xC3, 0x50}, 0)
+	if len(insts) != 3 {
+		t.Fatalf("expected 3 instructions, got %d", len(insts))
+	}
+	if !insts[1].Data {
+		t.Errorf("expected truncated instruction as data, got %v", insts[1])
+	}
+
+	// decoding carries on with the next byte
+	if insts[2].String() != "LD D, B" {
+		t.Errorf("expected %q, got %q", "LD D, B", insts[2].String())
+	}
+}
+
+func TestDisassembleMemory(t *testing.T) {
+	m := memory.NewMemory()
+	m.LoadProgram([]byte{0x31, 0xFE, 0xFF, 0xAF, 0xCB, 0x7C}, 0x0000)
+
+	insts := DisassembleMemory(m, 0x0000, 6)
+	expected := []string{"LD SP, $FFFE", "XOR A, A", "BIT 7, H"}
+	if len(insts) != len(expected) {
+		t.Fatalf("expected %d instructions, got %d", len(expected), len(insts))
+	}
+	for i := range expected {
+		if insts[i].String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], insts[i].String())
+		}
+	}
+}
+
+var loop = []byte{
+	0x3E, 0x05, // LD A, 5
+	0x3D,       // loop: DEC A
+	0x20, 0xFD, // JR NZ, loop
+	0xCD, 0x00, 0x02, // CALL done
+	0xD3, // illegal
+}
+
+var labels = map[uint16]string{0x0102: "loop", 0x0200: "done"}
+
+func TestWritePlain(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Disassemble(loop, 0x0100), Options{Format: Plain, Labels: labels})
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := `0100  3E 05     LD A, $05
+loop:
+0102  3D        DEC A
+0103  20 FD     JR NZ, loop
+0105  CD 00 02  CALL done
+0108  D3        DB $D3
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteRGBDS(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Disassemble(loop, 0x0100), Options{Format: RGBDS, Labels: labels})
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	expected := `	ld a, $05
+loop:
+	dec a
+	jr nz, loop
+	call done
+	db $D3
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Disassemble(loop, 0x0100), Options{Format: JSON, Labels: labels})
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	var out []struct {
+		Address  uint16   `json:"address"`
+		Bytes    string   `json:"bytes"`
+		Mnemonic string   `json:"mnemonic"`
+		Operands []string `json:"operands"`
+		Text     string   `json:"text"`
+		Target   *uint16  `json:"target"`
+		Label    string   `json:"label"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("expected valid JSON, got: %s", err)
+	}
+
+	if len(out) != 5 {
+		t.Fatalf("expected 5 instructions, got %d", len(out))
+	}
+
+	jr := out[2]
+	if jr.Address != 0x0103 || jr.Bytes != "20FD" || jr.Mnemonic != "JR" || jr.Text != "JR NZ, loop" {
+		t.Errorf("unexpected JR entry: %+v", jr)
+	}
+	if jr.Target == nil || *jr.Target != 0x0102 {
+		t.Errorf("expected target %04X, got %v", 0x0102, jr.Target)
+	}
+	if out[1].Label != "loop" {
+		t.Errorf("expected label loop, got %q", out[1].Label)
+	}
+	if out[4].Mnemonic != "DB" || out[4].Target != nil {
+		t.Errorf("unexpected data entry: %+v", out[4])
+	}
+}
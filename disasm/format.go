@@ -0,0 +1,203 @@
+package disasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format selects the output syntax
+type Format int
+
+const (
+	// Plain is a listing with addresses, raw bytes and the instruction names
+	// used by the cpu package
+	Plain Format = iota
+	// RGBDS is source code that can be assembled with RGBDS
+	RGBDS
+	// JSON is an array with one object per instruction
+	JSON
+)
+
+// Options controls how instructions are written
+type Options struct {
+	Format Format
+	// Labels replaces addresses with names, and marks where each name is defined
+	Labels map[uint16]string
+}
+
+// Write writes insts to w in the selected format
+func Write(w io.Writer, insts []Instruction, opts Options) error {
+	if opts.Format == JSON {
+		return writeJSON(w, insts, opts.Labels)
+	}
+
+	for _, inst := range insts {
+		label, ok := opts.Labels[inst.Address]
+		if ok {
+			if _, err := fmt.Fprintf(w, "%s:\n", label); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if opts.Format == RGBDS {
+			_, err = fmt.Fprintf(w, "\t%s\n", inst.rgbds(opts.Labels))
+		} else {
+			_, err = fmt.Fprintf(w, "%04X  %-9s %s\n", inst.Address, hex(inst.Bytes), inst.text(opts.Labels))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the instruction in the plain syntax, e.g. "JR NZ, $0105"
+func (i Instruction) String() string {
+	return i.text(nil)
+}
+
+func (i Instruction) text(labels map[uint16]string) string {
+	if i.Data {
+		return fmt.Sprintf("DB $%02X", i.Bytes[0])
+	}
+
+	ops := make([]string, len(i.Info.Operands))
+	for n, o := range i.Info.Operands {
+		ops[n] = i.operand(o, labels)
+	}
+	return join(i.Info.Mnemonic, ops)
+}
+
+// operand replaces the immediate placeholder in o with its value
+func (i Instruction) operand(o string, labels map[uint16]string) string {
+	switch o {
+	case "n", "(n)":
+		return strings.Replace(o, "n", fmt.Sprintf("$%02X", i.Bytes[1]), 1)
+	case "nn", "(nn)":
+		return strings.Replace(o, "nn", address(word(i.Bytes), labels), 1)
+	case "e":
+		if i.HasTarget {
+			return address(i.Target, labels)
+		}
+		return fmt.Sprintf("%d", int8(i.Bytes[1]))
+	case "SP+e":
+		return fmt.Sprintf("SP%+d", int8(i.Bytes[1]))
+	}
+
+	if i.Info.Mnemonic == "RST" {
+		return address(i.Target, labels)
+	}
+	return o
+}
+
+// rgbds returns the instruction in RGBDS syntax
+func (i Instruction) rgbds(labels map[uint16]string) string {
+	if i.Data {
+		return fmt.Sprintf("db $%02X", i.Bytes[0])
+	}
+
+	mnemonic := strings.ToLower(i.Info.Mnemonic)
+	ops := make([]string, len(i.Info.Operands))
+	for n, o := range i.Info.Operands {
+		switch {
+		case o == "(n)":
+			// LDH addresses the high page
+			ops[n] = "[" + address(0xFF00+uint16(i.Bytes[1]), labels) + "]"
+		case o == "(C)":
+			mnemonic = "ldh"
+			ops[n] = "[c]"
+		case o == "(HL)" && (mnemonic == "ldi" || mnemonic == "ldd"):
+			ops[n] = "[hl+]"
+			if mnemonic == "ldd" {
+				ops[n] = "[hl-]"
+			}
+		case o == "(HL)" && mnemonic == "jp":
+			ops[n] = "hl"
+		case o == "SP+e":
+			ops[n] = fmt.Sprintf("sp%+d", int8(i.Bytes[1]))
+		default:
+			op := i.operand(o, labels)
+			if op == o {
+				// a register or condition
+				op = strings.ToLower(op)
+			}
+			if strings.HasPrefix(op, "(") {
+				op = "[" + strings.TrimSuffix(strings.TrimPrefix(op, "("), ")") + "]"
+			}
+			ops[n] = op
+		}
+	}
+
+	if mnemonic == "ldi" || mnemonic == "ldd" {
+		mnemonic = "ld"
+	}
+	return join(mnemonic, ops)
+}
+
+type jsonInstruction struct {
+	Address  uint16   `json:"address"`
+	Bytes    string   `json:"bytes"`
+	Mnemonic string   `json:"mnemonic"`
+	Operands []string `json:"operands,omitempty"`
+	Text     string   `json:"text"`
+	Target   *uint16  `json:"target,omitempty"`
+	Label    string   `json:"label,omitempty"`
+}
+
+func writeJSON(w io.Writer, insts []Instruction, labels map[uint16]string) error {
+	out := make([]jsonInstruction, len(insts))
+	for n, inst := range insts {
+		j := jsonInstruction{
+			Address: inst.Address,
+			Bytes:   strings.Replace(hex(inst.Bytes), " ", "", -1),
+			Text:    inst.text(labels),
+			Label:   labels[inst.Address],
+		}
+
+		if inst.Data {
+			j.Mnemonic = "DB"
+			j.Operands = []string{fmt.Sprintf("$%02X", inst.Bytes[0])}
+		} else {
+			j.Mnemonic = inst.Info.Mnemonic
+			for _, o := range inst.Info.Operands {
+				j.Operands = append(j.Operands, inst.operand(o, labels))
+			}
+		}
+
+		if inst.HasTarget {
+			target := inst.Target
+			j.Target = &target
+		}
+		out[n] = j
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// address returns the label for addr, or addr in hex
+func address(addr uint16, labels map[uint16]string) string {
+	if label, ok := labels[addr]; ok {
+		return label
+	}
+	return fmt.Sprintf("$%04X", addr)
+}
+
+func hex(b []byte) string {
+	s := make([]string, len(b))
+	for i, v := range b {
+		s[i] = fmt.Sprintf("%02X", v)
+	}
+	return strings.Join(s, " ")
+}
+
+func join(mnemonic string, ops []string) string {
+	if len(ops) == 0 {
+		return mnemonic
+	}
+	return mnemonic + " " + strings.Join(ops, ", ")
+}
@@ -3,7 +3,47 @@ package memory
 const MemorySize = 65536
 
 type Memory struct {
-	data [MemorySize]byte
+	data      [MemorySize]byte
+	hooks     []WriteHook
+	observers []Observer
+}
+
+// Bus is the memory interface the CPU cores run against. Memory implements it.
+type Bus interface {
+	Read(addr uint16) byte
+	Write(addr uint16, val byte)
+}
+
+// Inspectable is implemented by buses that support debugging tools: reads
+// without side effects and subscriptions to accesses. Memory implements it.
+type Inspectable interface {
+	Bus
+	Peek(addr uint16) byte
+	AddObserver(o Observer)
+	RemoveObserver(o Observer)
+	AddWriteHook(h WriteHook)
+	RemoveWriteHook(h WriteHook)
+}
+
+// Peek reads addr without side effects if b is Inspectable, and with Read
+// otherwise
+func Peek(b Bus, addr uint16) byte {
+	if i, ok := b.(Inspectable); ok {
+		return i.Peek(addr)
+	}
+	return b.Read(addr)
+}
+
+// Observer is notified of every read and write
+type Observer interface {
+	MemoryRead(addr uint16, val byte)
+	MemoryWrite(addr uint16, val byte)
+}
+
+// WriteHook is notified of every write. It's cheaper than an Observer for
+// components that don't need the reads, such as caches of decoded code.
+type WriteHook interface {
+	MemoryWritten(addr uint16)
 }
 
 func NewMemory() *Memory {
@@ -19,17 +59,96 @@ func (m *Memory) LoadProgram(p []byte, addr uint16) {
 }
 
 func (m *Memory) Read(addr uint16) byte {
+	if m.observers != nil {
+		m.notifyRead(addr)
+	}
+	return m.data[addr]
+}
+
+// Peek reads addr without notifying observers, for tools that inspect memory
+// without taking part in the emulation
+func (m *Memory) Peek(addr uint16) byte {
 	return m.data[addr]
 }
 
 func (m *Memory) Write(addr uint16, val byte) {
 	m.data[addr] = val
+	if m.hooks != nil || m.observers != nil {
+		m.notifyWrite(addr)
+	}
+}
+
+// AddObserver subscribes o to reads and writes. LoadProgram and Peek do not
+// notify observers.
+func (m *Memory) AddObserver(o Observer) {
+	m.observers = append(m.observers, o)
+}
+
+// RemoveObserver unsubscribes o
+func (m *Memory) RemoveObserver(o Observer) {
+	m.observers = remove(m.observers, o)
+}
+
+// notifyRead and notifyWrite are kept apart from Read and Write so that those
+// can still be inlined
+//
+//go:noinline
+func (m *Memory) notifyRead(addr uint16) {
+	for _, o := range m.observers {
+		o.MemoryRead(addr, m.data[addr])
+	}
+}
+
+//go:noinline
+func (m *Memory) notifyWrite(addr uint16) {
+	for _, h := range m.hooks {
+		h.MemoryWritten(addr)
+	}
+	for _, o := range m.observers {
+		o.MemoryWrite(addr, m.data[addr])
+	}
+}
+
+// remove returns a copy of observers without o, so that removing an observer
+// while the others are being notified is safe
+func remove(observers []Observer, o Observer) []Observer {
+	var out []Observer
+	for _, v := range observers {
+		if v != o {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// AddWriteHook subscribes h to writes. LoadProgram does not notify it.
+func (m *Memory) AddWriteHook(h WriteHook) {
+	m.hooks = append(m.hooks, h)
+}
+
+// RemoveWriteHook unsubscribes h
+func (m *Memory) RemoveWriteHook(h WriteHook) {
+	var out []WriteHook
+	for _, v := range m.hooks {
+		if v != h {
+			out = append(out, v)
+		}
+	}
+	m.hooks = out
+}
+
+// Read16 reads a little-endian 16-bit value
+func (m *Memory) Read16(addr uint16) uint16 {
+	lo := m.Read(addr)
+	hi := m.Read(addr + 1)
+	return uint16(hi)<<8 | uint16(lo)
 }
 
+// Write16 writes a little-endian 16-bit value
 func (m *Memory) Write16(addr uint16, val uint16) {
 	hi, lo := split(val)
-	m.data[addr] = hi
-	m.data[addr+1] = lo
+	m.Write(addr, lo)
+	m.Write(addr+1, hi)
 }
 
 func split(v uint16) (hi, lo byte) {
@@ -1,6 +1,8 @@
 package memory_test
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/danicat/gogoboy/memory"
@@ -15,3 +17,83 @@ func TestLoadProgram(t *testing.T) {
 		t.Fatalf("expected 3, got %d", b)
 	}
 }
+
+func TestReadWrite16(t *testing.T) {
+	m := memory.NewMemory()
+	m.Write16(0xC000, 0xBEEF)
+
+	if lo := m.Read(0xC000); lo != 0xEF {
+		t.Errorf("expected low byte %x, got %x", 0xEF, lo)
+	}
+	if hi := m.Read(0xC001); hi != 0xBE {
+		t.Errorf("expected high byte %x, got %x", 0xBE, hi)
+	}
+	if v := m.Read16(0xC000); v != 0xBEEF {
+		t.Errorf("expected %x, got %x", 0xBEEF, v)
+	}
+}
+
+type writeHook []uint16
+
+func (w *writeHook) MemoryWritten(addr uint16) {
+	*w = append(*w, addr)
+}
+
+func TestWriteHook(t *testing.T) {
+	m := memory.NewMemory()
+
+	first, second := &writeHook{}, &writeHook{}
+	m.AddWriteHook(first)
+	m.AddWriteHook(second)
+
+	m.Write(0xC000, 0x01)
+	m.Write16(0xD000, 0xBEEF)
+	m.LoadProgram([]byte{0x00}, 0)
+
+	m.RemoveWriteHook(first)
+	m.Write(0xC001, 0x01)
+
+	expected := writeHook{0xC000, 0xD000, 0xD001}
+	if !reflect.DeepEqual(*first, expected) {
+		t.Errorf("expected writes %x, got %x", expected, *first)
+	}
+	expected = append(expected, 0xC001)
+	if !reflect.DeepEqual(*second, expected) {
+		t.Errorf("expected writes %x, got %x", expected, *second)
+	}
+}
+
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) MemoryRead(addr uint16, val byte) {
+	r.events = append(r.events, fmt.Sprintf("R %04X %02X", addr, val))
+}
+
+func (r *recorder) MemoryWrite(addr uint16, val byte) {
+	r.events = append(r.events, fmt.Sprintf("W %04X %02X", addr, val))
+}
+
+func TestObserver(t *testing.T) {
+	m := memory.NewMemory()
+	m.LoadProgram([]byte{0x12}, 0)
+
+	r := &recorder{}
+	m.AddObserver(r)
+
+	m.Read(0x0000)
+	m.Peek(0x0000)
+	m.Write(0xC000, 0x34)
+	m.Write16(0xC001, 0xBEEF)
+	m.Read16(0xC001)
+
+	m.RemoveObserver(r)
+	m.Read(0x0000)
+	m.Write(0xC000, 0x56)
+
+	expected := []string{"R 0000 12", "W C000 34", "W C001 EF", "W C002 BE", "R C001 EF", "R C002 BE"}
+	if !reflect.DeepEqual(r.events, expected) {
+		t.Errorf("expected %v, got %v", expected, r.events)
+	}
+}
@@ -0,0 +1,279 @@
+package z80
+
+const flagsXY = FlagX | FlagY
+
+// sz holds the S, Z, X and Y flags for each result, szp adds the parity flag
+var sz, szp [256]byte
+
+func init() {
+	for i := 0; i < 256; i++ {
+		v := byte(i)
+		sz[i] = v & (FlagS | flagsXY)
+		if v == 0 {
+			sz[i] |= FlagZ
+		}
+
+		szp[i] = sz[i]
+		if parity(v) {
+			szp[i] |= FlagPV
+		}
+	}
+}
+
+// parity reports whether v has an even number of bits set
+func parity(v byte) bool {
+	v ^= v >> 4
+	v ^= v >> 2
+	v ^= v >> 1
+	return v&1 == 0
+}
+
+func (z *Z80) carry() byte {
+	return z.F & FlagC
+}
+
+func (z *Z80) add8(a, b, carry byte) byte {
+	r := int(a) + int(b) + int(carry)
+	res := byte(r)
+
+	f := sz[res] | (a^b^res)&FlagH
+	if (a^b)&0x80 == 0 && (a^res)&0x80 != 0 {
+		f |= FlagPV
+	}
+	if r > 0xFF {
+		f |= FlagC
+	}
+
+	z.F = f
+	return res
+}
+
+func (z *Z80) sub8(a, b, carry byte) byte {
+	r := int(a) - int(b) - int(carry)
+	res := byte(r)
+
+	f := sz[res] | (a^b^res)&FlagH | FlagN
+	if (a^b)&0x80 != 0 && (a^res)&0x80 != 0 {
+		f |= FlagPV
+	}
+	if r < 0 {
+		f |= FlagC
+	}
+
+	z.F = f
+	return res
+}
+
+// cp compares A with v. X and Y come from v rather than from the result.
+func (z *Z80) cp(v byte) {
+	z.sub8(z.A, v, 0)
+	z.F = z.F&^flagsXY | v&flagsXY
+}
+
+// alu runs operation op of the ADD, ADC, SUB, SBC, AND, XOR, OR, CP encoding
+// on A and v
+func (z *Z80) alu(op, v byte) {
+	switch op {
+	case 0:
+		z.A = z.add8(z.A, v, 0)
+	case 1:
+		z.A = z.add8(z.A, v, z.carry())
+	case 2:
+		z.A = z.sub8(z.A, v, 0)
+	case 3:
+		z.A = z.sub8(z.A, v, z.carry())
+	case 4:
+		z.A &= v
+		z.F = szp[z.A] | FlagH
+	case 5:
+		z.A ^= v
+		z.F = szp[z.A]
+	case 6:
+		z.A |= v
+		z.F = szp[z.A]
+	case 7:
+		z.cp(v)
+	}
+}
+
+func (z *Z80) inc8(v byte) byte {
+	r := v + 1
+	z.F = z.carry() | sz[r] | (v^r)&FlagH
+	if r == 0x80 {
+		z.F |= FlagPV
+	}
+	return r
+}
+
+func (z *Z80) dec8(v byte) byte {
+	r := v - 1
+	z.F = z.carry() | sz[r] | (v^r)&FlagH | FlagN
+	if r == 0x7F {
+		z.F |= FlagPV
+	}
+	return r
+}
+
+// add16 adds b to a for ADD HL, rr. S, Z and P/V are not affected.
+func (z *Z80) add16(a, b uint16) uint16 {
+	r := uint32(a) + uint32(b)
+	res := uint16(r)
+
+	z.F = z.F&(FlagS|FlagZ|FlagPV) | byte(res>>8)&flagsXY | byte((a^b^res)>>8)&FlagH
+	if r > 0xFFFF {
+		z.F |= FlagC
+	}
+	return res
+}
+
+func (z *Z80) adc16(a, b uint16) uint16 {
+	r := uint32(a) + uint32(b) + uint32(z.carry())
+	res := uint16(r)
+
+	z.F = byte(res>>8)&(FlagS|flagsXY) | byte((a^b^res)>>8)&FlagH
+	if res == 0 {
+		z.F |= FlagZ
+	}
+	if (a^b)&0x8000 == 0 && (a^res)&0x8000 != 0 {
+		z.F |= FlagPV
+	}
+	if r > 0xFFFF {
+		z.F |= FlagC
+	}
+	return res
+}
+
+func (z *Z80) sbc16(a, b uint16) uint16 {
+	r := int32(a) - int32(b) - int32(z.carry())
+	res := uint16(r)
+
+	z.F = byte(res>>8)&(FlagS|flagsXY) | byte((a^b^res)>>8)&FlagH | FlagN
+	if res == 0 {
+		z.F |= FlagZ
+	}
+	if (a^b)&0x8000 != 0 && (a^res)&0x8000 != 0 {
+		z.F |= FlagPV
+	}
+	if r < 0 {
+		z.F |= FlagC
+	}
+	return res
+}
+
+func (z *Z80) daa() {
+	var correction byte
+	carry := z.carry()
+
+	if z.F&FlagH != 0 || z.A&0x0F > 9 {
+		correction |= 0x06
+	}
+	if carry != 0 || z.A > 0x99 {
+		correction |= 0x60
+		carry = FlagC
+	}
+
+	var half byte
+	if z.F&FlagN != 0 {
+		if z.F&FlagH != 0 && z.A&0x0F < 6 {
+			half = FlagH
+		}
+		z.A -= correction
+	} else {
+		if z.A&0x0F > 9 {
+			half = FlagH
+		}
+		z.A += correction
+	}
+
+	z.F = szp[z.A] | z.F&FlagN | half | carry
+}
+
+func (z *Z80) cpl() {
+	z.A = ^z.A
+	z.F = z.F&(FlagS|FlagZ|FlagPV|FlagC) | z.A&flagsXY | FlagH | FlagN
+}
+
+func (z *Z80) scf() {
+	z.F = z.F&(FlagS|FlagZ|FlagPV) | z.A&flagsXY | FlagC
+}
+
+// ccf inverts the carry flag, moving the old carry to H
+func (z *Z80) ccf() {
+	c := z.carry()
+	z.F = z.F&(FlagS|FlagZ|FlagPV) | z.A&flagsXY | c<<4 | (c ^ FlagC)
+}
+
+// rotateA runs RLCA, RRCA, RLA or RRA, which leave S, Z and P/V alone
+func (z *Z80) rotateA(op byte) {
+	f := z.F & (FlagS | FlagZ | FlagPV)
+	z.A = z.rot(op, z.A)
+	z.F = f | z.A&flagsXY | z.carry()
+}
+
+// rot runs operation op of the RLC, RRC, RL, RR, SLA, SRA, SLL, SRL encoding
+func (z *Z80) rot(op, v byte) byte {
+	var r, c byte
+	switch op {
+	case 0:
+		c = v >> 7
+		r = v<<1 | c
+	case 1:
+		c = v & 1
+		r = v>>1 | c<<7
+	case 2:
+		c = v >> 7
+		r = v<<1 | z.carry()
+	case 3:
+		c = v & 1
+		r = v>>1 | z.carry()<<7
+	case 4:
+		c = v >> 7
+		r = v << 1
+	case 5:
+		c = v & 1
+		r = v>>1 | v&0x80
+	case 6:
+		c = v >> 7
+		r = v<<1 | 1
+	case 7:
+		c = v & 1
+		r = v >> 1
+	}
+
+	z.F = szp[r] | c
+	return r
+}
+
+// bit tests bit b of v. X and Y come from xy: the operand for registers, the
+// high byte of an internal address for memory.
+func (z *Z80) bit(b, v, xy byte) {
+	r := v & (1 << b)
+	z.F = z.carry() | FlagH | xy&flagsXY | r&FlagS
+	if r == 0 {
+		z.F |= FlagZ | FlagPV
+	}
+}
+
+func (z *Z80) neg() {
+	z.A = z.sub8(0, z.A, 0)
+}
+
+// rrd rotates the low nibble of A and the byte at HL right by one nibble
+func (z *Z80) rrd() {
+	addr := z.HL()
+	v := z.read(addr)
+	z.write(addr, z.A<<4|v>>4)
+	z.A = z.A&0xF0 | v&0x0F
+	z.F = z.carry() | szp[z.A]
+	z.wz = addr + 1
+}
+
+// rld rotates the low nibble of A and the byte at HL left by one nibble
+func (z *Z80) rld() {
+	addr := z.HL()
+	v := z.read(addr)
+	z.write(addr, v<<4|z.A&0x0F)
+	z.A = z.A&0xF0 | v>>4
+	z.F = z.carry() | szp[z.A]
+	z.wz = addr + 1
+}
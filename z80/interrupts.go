@@ -0,0 +1,84 @@
+package z80
+
+// NMI triggers a non-maskable interrupt. It's serviced before the next
+// instruction, even when interrupts are disabled.
+func (z *Z80) NMI() {
+	z.nmi = true
+}
+
+// RequestInterrupt asserts the INT line, with data as the value the
+// interrupting device puts on the data bus. The line stays asserted until
+// the interrupt is accepted or ClearInterrupt is called.
+func (z *Z80) RequestInterrupt(data byte) {
+	z.irq = true
+	z.irqData = data
+}
+
+// ClearInterrupt deasserts the INT line
+func (z *Z80) ClearInterrupt() {
+	z.irq = false
+}
+
+// interrupt services a pending interrupt, if any. It returns true when an
+// interrupt was accepted.
+func (z *Z80) interrupt() bool {
+	if z.nmi {
+		z.nmi = false
+		z.halted = false
+		z.refresh()
+
+		// IFF2 keeps the previous state so that RETN can restore it
+		z.IFF1 = false
+		z.rst(0x66)
+		z.cycles += 11
+		return true
+	}
+
+	// interrupts are not accepted until the instruction after EI has run
+	if z.eiDelay {
+		z.eiDelay = false
+		return false
+	}
+
+	if !z.irq || !z.IFF1 {
+		return false
+	}
+
+	z.irq = false
+	z.halted = false
+	z.refresh()
+	z.IFF1 = false
+	z.IFF2 = false
+
+	switch z.IM {
+	case 0:
+		z.interruptMode0(z.irqData)
+	case 1:
+		z.rst(0x38)
+		z.cycles += 13
+	case 2:
+		// the vector table entry is read from the address formed by I and the
+		// data bus
+		z.push(z.PC)
+		z.PC = z.read16(pair(z.I, z.irqData))
+		z.wz = z.PC
+		z.cycles += 19
+	}
+
+	return true
+}
+
+// interruptMode0 executes the instruction on the data bus, normally one of
+// the RST instructions. Only single byte instructions are supported.
+func (z *Z80) interruptMode0(data byte) {
+	inst := &opcodes[data]
+	if inst.exec == nil {
+		// a prefix would need more bytes from the interrupting device
+		z.cycles += 6
+		return
+	}
+
+	// the acknowledge cycle takes two wait states more than an opcode fetch
+	z.cycles += inst.cycles + 2
+	inst.exec(z)
+}
@@ -0,0 +1,143 @@
+package z80
+
+import "testing"
+
+func TestInterruptModes(t *testing.T) {
+	tbl := []struct {
+		name       string
+		mode       byte
+		data       byte
+		expectedPC uint16
+		cycles     int
+	}{
+		{"IM 0 RST 28H", 0, 0xEF, 0x0028, 13},
+		{"IM 1", 1, 0x00, 0x0038, 13},
+		{"IM 2", 2, 0x10, 0x1234, 19},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z, m := newTestZ80([]byte{0x00, 0x00})
+			m.Write16(0x4010, 0x1234)
+			z.I = 0x40
+			z.IM = tc.mode
+			z.IFF1, z.IFF2 = true, true
+			z.SP = 0x8000
+			z.PC = 1
+
+			z.RequestInterrupt(tc.data)
+			cycles := z.Step()
+
+			if z.PC != tc.expectedPC {
+				t.Errorf("expected PC=%04x, got %04x", tc.expectedPC, z.PC)
+			}
+			if cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, cycles)
+			}
+			if z.IFF1 || z.IFF2 {
+				t.Errorf("expected interrupts to be disabled")
+			}
+			if ret := m.Read16(z.SP); ret != 0x0001 {
+				t.Errorf("expected return address %04x, got %04x", 0x0001, ret)
+			}
+
+			// the line is released once the interrupt is accepted
+			z.IFF1 = true
+			z.Step()
+			if z.PC != tc.expectedPC+1 {
+				t.Errorf("expected a single interrupt, got PC=%04x", z.PC)
+			}
+		})
+	}
+}
+
+func TestInterruptDisabled(t *testing.T) {
+	z, _ := newTestZ80([]byte{0xF3, 0x00, 0x00})
+	z.IM = 1
+	z.IFF1 = true
+
+	z.Step() // DI
+	z.RequestInterrupt(0xFF)
+	z.Step()
+
+	if z.PC != 2 {
+		t.Errorf("expected PC=%04x, got %04x", 2, z.PC)
+	}
+
+	z.ClearInterrupt()
+	z.IFF1 = true
+	z.Step()
+	if z.PC != 3 {
+		t.Errorf("expected PC=%04x, got %04x", 3, z.PC)
+	}
+}
+
+func TestEIDelay(t *testing.T) {
+	// EI, NOP, NOP
+	z, _ := newTestZ80([]byte{0xFB, 0x00, 0x00})
+	z.IM = 1
+	z.SP = 0x8000
+	z.RequestInterrupt(0xFF)
+
+	z.Step()
+	z.Step()
+	if z.PC != 2 {
+		t.Fatalf("expected the instruction after EI to run, got PC=%04x", z.PC)
+	}
+
+	z.Step()
+	if z.PC != 0x0038 {
+		t.Errorf("expected PC=%04x, got %04x", 0x0038, z.PC)
+	}
+}
+
+func TestNMI(t *testing.T) {
+	// HALT, then RETN at the NMI handler
+	z, m := newTestZ80([]byte{0x76})
+	m.Write(0x0066, 0xED)
+	m.Write(0x0067, 0x45)
+	z.SP = 0x8000
+	z.IFF1, z.IFF2 = true, true
+
+	z.Step()
+	z.Step()
+	if !z.Halted() {
+		t.Fatalf("expected the CPU to be halted")
+	}
+
+	z.NMI()
+	if cycles := z.Step(); cycles != 11 {
+		t.Errorf("expected cycles=%d, got %d", 11, cycles)
+	}
+	if z.PC != 0x0066 || z.Halted() {
+		t.Errorf("expected PC=0066 and not halted, got PC=%04x halted=%v", z.PC, z.Halted())
+	}
+	if z.IFF1 || !z.IFF2 {
+		t.Errorf("expected IFF1=false IFF2=true, got IFF1=%v IFF2=%v", z.IFF1, z.IFF2)
+	}
+
+	// RETN returns after the HALT and restores IFF1
+	z.Step()
+	if z.PC != 0x0001 || !z.IFF1 {
+		t.Errorf("expected PC=0001 IFF1=true, got PC=%04x IFF1=%v", z.PC, z.IFF1)
+	}
+}
+
+func TestHaltWakeUp(t *testing.T) {
+	z, _ := newTestZ80([]byte{0x76, 0x00})
+	z.IM = 1
+	z.IFF1, z.IFF2 = true, true
+	z.SP = 0x8000
+
+	z.Step()
+	z.Step()
+	if !z.Halted() || z.PC != 1 {
+		t.Fatalf("expected halted at PC=0001, got halted=%v PC=%04x", z.Halted(), z.PC)
+	}
+
+	z.RequestInterrupt(0xFF)
+	z.Step()
+	if z.Halted() || z.PC != 0x0038 {
+		t.Errorf("expected PC=0038 and not halted, got PC=%04x halted=%v", z.PC, z.Halted())
+	}
+}
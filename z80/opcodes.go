@@ -0,0 +1,630 @@
+package z80
+
+import "fmt"
+
+// opcode describes one instruction. cycles is the number of T-states it takes
+// without prefixes and, for conditional instructions, when the condition is
+// not met. exec adds the T-states of taken branches, repeats and (IX+d).
+type opcode struct {
+	name   string
+	cycles int
+	exec   func(z *Z80)
+}
+
+// opcodes, cbOpcodes and edOpcodes are indexed by the opcode byte. DD and FD
+// prefixed instructions run from opcodes with H, L and (HL) replaced by the
+// index registers; DDCB and FDCB instructions run from indexedCB.
+var opcodes, cbOpcodes, edOpcodes [256]opcode
+
+var (
+	regNames  = [8]string{"B", "C", "D", "E", "H", "L", "(HL)", "A"}
+	rpNames   = [4]string{"BC", "DE", "HL", "SP"}
+	rp2Names  = [4]string{"BC", "DE", "HL", "AF"}
+	condNames = [8]string{"NZ", "Z", "NC", "C", "PO", "PE", "P", "M"}
+	aluNames  = [8]string{"ADD A,", "ADC A,", "SUB A,", "SBC A,", "AND A,", "XOR A,", "OR A,", "CP A,"}
+	rotNames  = [8]string{"RLC", "RRC", "RL", "RR", "SLA", "SRA", "SLL", "SRL"}
+)
+
+func init() {
+	for i := 0; i < 256; i++ {
+		opcodes[i] = decode(byte(i))
+		cbOpcodes[i] = decodeCB(byte(i))
+		edOpcodes[i] = decodeED(byte(i))
+	}
+}
+
+// decode builds the unprefixed instruction op. The opcode is split into the
+// fields x (bits 7-6), y (bits 5-3) and z (bits 2-0), with y further split
+// into p (bits 5-4) and q (bit 3).
+func decode(op byte) opcode {
+	x, y, zz := op>>6, op>>3&7, op&7
+	p, q := y>>1, y&1
+
+	switch x {
+	case 0:
+		return decodeX0(y, zz, p, q)
+
+	case 1:
+		switch {
+		case y == 6 && zz == 6:
+			return opcode{"HALT", 4, func(z *Z80) { z.halted = true }}
+		case y == 6:
+			// the source register ignores the prefix: LD (IX+d), H stores H
+			return opcode{"LD (HL), " + regNames[zz], 7, func(z *Z80) { z.write(z.addr(), *z.regs(zz)) }}
+		case zz == 6:
+			return opcode{"LD " + regNames[y] + ", (HL)", 7, func(z *Z80) { a := z.addr(); *z.regs(y) = z.read(a) }}
+		}
+		return opcode{"LD " + regNames[y] + ", " + regNames[zz], 4, func(z *Z80) { z.setReg(y, z.reg(zz)) }}
+
+	case 2:
+		if zz == 6 {
+			return opcode{aluNames[y] + " (HL)", 7, func(z *Z80) { z.alu(y, z.read(z.addr())) }}
+		}
+		return opcode{aluNames[y] + " " + regNames[zz], 4, func(z *Z80) { z.alu(y, z.reg(zz)) }}
+	}
+
+	return decodeX3(y, zz, p, q)
+}
+
+func decodeX0(y, zz, p, q byte) opcode {
+	switch zz {
+	case 0:
+		switch y {
+		case 0:
+			return opcode{"NOP", 4, func(z *Z80) {}}
+		case 1:
+			return opcode{"EX AF, AF'", 4, func(z *Z80) { z.exAF() }}
+		case 2:
+			return opcode{"DJNZ e", 8, func(z *Z80) {
+				z.B--
+				z.jumpRelativeIf(z.B != 0, 5)
+			}}
+		case 3:
+			return opcode{"JR e", 12, func(z *Z80) { z.jumpRelativeIf(true, 0) }}
+		}
+		c := y - 4
+		return opcode{"JR " + condNames[c] + ", e", 7, func(z *Z80) { z.jumpRelativeIf(z.cond(c), 5) }}
+
+	case 1:
+		if q == 0 {
+			return opcode{"LD " + rpNames[p] + ", nn", 10, func(z *Z80) { z.setRP(p, z.fetch16()) }}
+		}
+		return opcode{"ADD HL, " + rpNames[p], 11, func(z *Z80) {
+			hl := z.indexedHL()
+			z.wz = hl + 1
+			z.setIndexedHL(z.add16(hl, z.rp(p)))
+		}}
+
+	case 2:
+		return decodeLoadIndirect(p, q)
+
+	case 3:
+		if q == 0 {
+			return opcode{"INC " + rpNames[p], 6, func(z *Z80) { z.setRP(p, z.rp(p)+1) }}
+		}
+		return opcode{"DEC " + rpNames[p], 6, func(z *Z80) { z.setRP(p, z.rp(p)-1) }}
+
+	case 4:
+		if y == 6 {
+			return opcode{"INC (HL)", 11, func(z *Z80) {
+				a := z.addr()
+				z.write(a, z.inc8(z.read(a)))
+			}}
+		}
+		return opcode{"INC " + regNames[y], 4, func(z *Z80) { z.setReg(y, z.inc8(z.reg(y))) }}
+
+	case 5:
+		if y == 6 {
+			return opcode{"DEC (HL)", 11, func(z *Z80) {
+				a := z.addr()
+				z.write(a, z.dec8(z.read(a)))
+			}}
+		}
+		return opcode{"DEC " + regNames[y], 4, func(z *Z80) { z.setReg(y, z.dec8(z.reg(y))) }}
+
+	case 6:
+		if y == 6 {
+			return opcode{"LD (HL), n", 10, func(z *Z80) {
+				a := z.addr()
+				if z.index != nil {
+					// the address is calculated while n is fetched
+					z.cycles -= 3
+				}
+				z.write(a, z.fetch())
+			}}
+		}
+		return opcode{"LD " + regNames[y] + ", n", 7, func(z *Z80) { z.setReg(y, z.fetch()) }}
+	}
+
+	switch y {
+	case 4:
+		return opcode{"DAA", 4, func(z *Z80) { z.daa() }}
+	case 5:
+		return opcode{"CPL", 4, func(z *Z80) { z.cpl() }}
+	case 6:
+		return opcode{"SCF", 4, func(z *Z80) { z.scf() }}
+	case 7:
+		return opcode{"CCF", 4, func(z *Z80) { z.ccf() }}
+	}
+	return opcode{rotNames[y] + "A", 4, func(z *Z80) { z.rotateA(y) }}
+}
+
+// decodeLoadIndirect builds the loads between A or HL and memory addressed by
+// BC, DE or nn
+func decodeLoadIndirect(p, q byte) opcode {
+	switch p<<1 | q {
+	case 0:
+		return opcode{"LD (BC), A", 7, func(z *Z80) { z.storeA(z.BC()) }}
+	case 1:
+		return opcode{"LD A, (BC)", 7, func(z *Z80) { z.loadA(z.BC()) }}
+	case 2:
+		return opcode{"LD (DE), A", 7, func(z *Z80) { z.storeA(z.DE()) }}
+	case 3:
+		return opcode{"LD A, (DE)", 7, func(z *Z80) { z.loadA(z.DE()) }}
+	case 4:
+		return opcode{"LD (nn), HL", 16, func(z *Z80) {
+			nn := z.fetch16()
+			z.write16(nn, z.indexedHL())
+			z.wz = nn + 1
+		}}
+	case 5:
+		return opcode{"LD HL, (nn)", 16, func(z *Z80) {
+			nn := z.fetch16()
+			z.setIndexedHL(z.read16(nn))
+			z.wz = nn + 1
+		}}
+	case 6:
+		return opcode{"LD (nn), A", 13, func(z *Z80) { z.storeA(z.fetch16()) }}
+	}
+	return opcode{"LD A, (nn)", 13, func(z *Z80) { z.loadA(z.fetch16()) }}
+}
+
+func decodeX3(y, zz, p, q byte) opcode {
+	switch zz {
+	case 0:
+		return opcode{"RET " + condNames[y], 5, func(z *Z80) {
+			if z.cond(y) {
+				z.ret()
+				z.cycles += 6
+			}
+		}}
+
+	case 1:
+		if q == 0 {
+			return opcode{"POP " + rp2Names[p], 10, func(z *Z80) { z.setRP2(p, z.pop()) }}
+		}
+		switch p {
+		case 0:
+			return opcode{"RET", 10, func(z *Z80) { z.ret() }}
+		case 1:
+			return opcode{"EXX", 4, func(z *Z80) { z.exx() }}
+		case 2:
+			return opcode{"JP (HL)", 4, func(z *Z80) { z.PC = z.indexedHL() }}
+		}
+		return opcode{"LD SP, HL", 6, func(z *Z80) { z.SP = z.indexedHL() }}
+
+	case 2:
+		return opcode{"JP " + condNames[y] + ", nn", 10, func(z *Z80) {
+			nn := z.fetch16()
+			z.wz = nn
+			if z.cond(y) {
+				z.PC = nn
+			}
+		}}
+
+	case 3:
+		return decodeX3Z3(y)
+
+	case 4:
+		return opcode{"CALL " + condNames[y] + ", nn", 10, func(z *Z80) { z.callIf(z.cond(y)) }}
+
+	case 5:
+		if q == 0 {
+			return opcode{"PUSH " + rp2Names[p], 11, func(z *Z80) { z.push(z.rp2(p)) }}
+		}
+		switch p {
+		case 0:
+			return opcode{"CALL nn", 10, func(z *Z80) { z.callIf(true) }}
+		case 1:
+			return opcode{"PREFIX DD", 4, nil}
+		case 2:
+			return opcode{"PREFIX ED", 4, nil}
+		}
+		return opcode{"PREFIX FD", 4, nil}
+
+	case 6:
+		return opcode{aluNames[y] + " n", 7, func(z *Z80) { z.alu(y, z.fetch()) }}
+	}
+
+	vector := uint16(y) * 8
+	return opcode{fmt.Sprintf("RST %02XH", vector), 11, func(z *Z80) { z.rst(vector) }}
+}
+
+func decodeX3Z3(y byte) opcode {
+	switch y {
+	case 0:
+		return opcode{"JP nn", 10, func(z *Z80) {
+			z.PC = z.fetch16()
+			z.wz = z.PC
+		}}
+	case 1:
+		return opcode{"PREFIX CB", 4, nil}
+	case 2:
+		return opcode{"OUT (n), A", 11, func(z *Z80) {
+			n := z.fetch()
+			z.out(pair(z.A, n), z.A)
+			z.wz = pair(z.A, n+1)
+		}}
+	case 3:
+		return opcode{"IN A, (n)", 11, func(z *Z80) {
+			port := pair(z.A, z.fetch())
+			z.A = z.in(port)
+			z.wz = port + 1
+		}}
+	case 4:
+		return opcode{"EX (SP), HL", 19, func(z *Z80) {
+			v := z.read16(z.SP)
+			z.write16(z.SP, z.indexedHL())
+			z.setIndexedHL(v)
+			z.wz = v
+		}}
+	case 5:
+		// EX DE, HL is not affected by a DD or FD prefix
+		return opcode{"EX DE, HL", 4, func(z *Z80) {
+			z.D, z.H = z.H, z.D
+			z.E, z.L = z.L, z.E
+		}}
+	case 6:
+		return opcode{"DI", 4, func(z *Z80) {
+			z.IFF1 = false
+			z.IFF2 = false
+		}}
+	}
+	return opcode{"EI", 4, func(z *Z80) {
+		z.IFF1 = true
+		z.IFF2 = true
+		z.eiDelay = true
+	}}
+}
+
+// decodeCB builds the CB prefixed instruction op. cycles include the prefix.
+func decodeCB(op byte) opcode {
+	x, y, zz := op>>6, op>>3&7, op&7
+
+	var name string
+	switch x {
+	case 0:
+		name = rotNames[y] + " " + regNames[zz]
+	case 1:
+		name = fmt.Sprintf("BIT %d, %s", y, regNames[zz])
+	case 2:
+		name = fmt.Sprintf("RES %d, %s", y, regNames[zz])
+	case 3:
+		name = fmt.Sprintf("SET %d, %s", y, regNames[zz])
+	}
+
+	if zz == 6 {
+		if x == 1 {
+			return opcode{name, 12, func(z *Z80) { z.bit(y, z.read(z.HL()), byte(z.wz>>8)) }}
+		}
+		return opcode{name, 15, func(z *Z80) {
+			addr := z.HL()
+			z.write(addr, z.bitOp(x, y, z.read(addr)))
+		}}
+	}
+
+	if x == 1 {
+		return opcode{name, 8, func(z *Z80) { z.bit(y, *z.regs(zz), *z.regs(zz)) }}
+	}
+	return opcode{name, 8, func(z *Z80) {
+		r := z.regs(zz)
+		*r = z.bitOp(x, y, *r)
+	}}
+}
+
+// bitOp runs the rotate, RES or SET instruction selected by x and y on v
+func (z *Z80) bitOp(x, y, v byte) byte {
+	switch x {
+	case 0:
+		return z.rot(y, v)
+	case 2:
+		return v &^ (1 << y)
+	}
+	return v | 1<<y
+}
+
+// indexedCB runs a DDCB or FDCB instruction: the prefix, CB, a displacement
+// and the opcode. The instructions other than BIT also copy the result to
+// the register selected by the low bits of the opcode, unless it's (HL).
+func (z *Z80) indexedCB() {
+	d := int8(z.fetch())
+	op := z.fetch()
+	x, y, zz := op>>6, op>>3&7, op&7
+
+	addr := *z.index + uint16(d)
+	z.wz = addr
+	v := z.read(addr)
+
+	if x == 1 {
+		z.bit(y, v, byte(addr>>8))
+		z.cycles += 16
+		return
+	}
+
+	v = z.bitOp(x, y, v)
+	z.write(addr, v)
+	if zz != 6 {
+		*z.regs(zz) = v
+	}
+	z.cycles += 19
+}
+
+// decodeED builds the ED prefixed instruction op. cycles include the prefix.
+// Opcodes without an instruction run as two NOPs.
+func decodeED(op byte) opcode {
+	x, y, zz := op>>6, op>>3&7, op&7
+	p, q := y>>1, y&1
+
+	switch {
+	case x == 1:
+		return decodeED1(y, zz, p, q)
+	case x == 2 && zz <= 3 && y >= 4:
+		return decodeBlock(y, zz)
+	}
+	return opcode{"NOP", 8, func(z *Z80) {}}
+}
+
+func decodeED1(y, zz, p, q byte) opcode {
+	switch zz {
+	case 0:
+		if y == 6 {
+			return opcode{"IN (C)", 12, func(z *Z80) { z.inC() }}
+		}
+		return opcode{"IN " + regNames[y] + ", (C)", 12, func(z *Z80) { *z.regs(y) = z.inC() }}
+
+	case 1:
+		if y == 6 {
+			return opcode{"OUT (C), 0", 12, func(z *Z80) { z.outC(0) }}
+		}
+		return opcode{"OUT (C), " + regNames[y], 12, func(z *Z80) { z.outC(*z.regs(y)) }}
+
+	case 2:
+		if q == 0 {
+			return opcode{"SBC HL, " + rpNames[p], 15, func(z *Z80) {
+				z.wz = z.HL() + 1
+				z.SetHL(z.sbc16(z.HL(), z.rp(p)))
+			}}
+		}
+		return opcode{"ADC HL, " + rpNames[p], 15, func(z *Z80) {
+			z.wz = z.HL() + 1
+			z.SetHL(z.adc16(z.HL(), z.rp(p)))
+		}}
+
+	case 3:
+		if q == 0 {
+			return opcode{"LD (nn), " + rpNames[p], 20, func(z *Z80) {
+				nn := z.fetch16()
+				z.write16(nn, z.rp(p))
+				z.wz = nn + 1
+			}}
+		}
+		return opcode{"LD " + rpNames[p] + ", (nn)", 20, func(z *Z80) {
+			nn := z.fetch16()
+			z.setRP(p, z.read16(nn))
+			z.wz = nn + 1
+		}}
+
+	case 4:
+		return opcode{"NEG", 8, func(z *Z80) { z.neg() }}
+
+	case 5:
+		name := "RETN"
+		if y == 1 {
+			name = "RETI"
+		}
+		return opcode{name, 14, func(z *Z80) {
+			z.IFF1 = z.IFF2
+			z.ret()
+		}}
+
+	case 6:
+		mode := [8]byte{0, 0, 1, 2, 0, 0, 1, 2}[y]
+		return opcode{fmt.Sprintf("IM %d", mode), 8, func(z *Z80) { z.IM = mode }}
+	}
+
+	switch y {
+	case 0:
+		return opcode{"LD I, A", 9, func(z *Z80) { z.I = z.A }}
+	case 1:
+		return opcode{"LD R, A", 9, func(z *Z80) { z.R = z.A }}
+	case 2:
+		return opcode{"LD A, I", 9, func(z *Z80) { z.loadSpecial(z.I) }}
+	case 3:
+		return opcode{"LD A, R", 9, func(z *Z80) { z.loadSpecial(z.R) }}
+	case 4:
+		return opcode{"RRD", 18, func(z *Z80) { z.rrd() }}
+	case 5:
+		return opcode{"RLD", 18, func(z *Z80) { z.rld() }}
+	}
+	return opcode{"NOP", 8, func(z *Z80) {}}
+}
+
+// decodeBlock builds the block transfer, search and I/O instructions
+func decodeBlock(y, zz byte) opcode {
+	names := [4][4]string{
+		{"LDI", "CPI", "INI", "OUTI"},
+		{"LDD", "CPD", "IND", "OUTD"},
+		{"LDIR", "CPIR", "INIR", "OTIR"},
+		{"LDDR", "CPDR", "INDR", "OTDR"},
+	}
+
+	step := uint16(1)
+	if y&1 == 1 {
+		step = 0xFFFF
+	}
+	repeat := y >= 6
+
+	ops := [4]func(z *Z80, step uint16, repeat bool){
+		(*Z80).blockLoad,
+		(*Z80).blockCompare,
+		(*Z80).blockIn,
+		(*Z80).blockOut,
+	}
+	f := ops[zz]
+
+	return opcode{names[y-4][zz], 16, func(z *Z80) { f(z, step, repeat) }}
+}
+
+// loadA loads A from addr
+func (z *Z80) loadA(addr uint16) {
+	z.A = z.read(addr)
+	z.wz = addr + 1
+}
+
+// storeA stores A at addr
+func (z *Z80) storeA(addr uint16) {
+	z.write(addr, z.A)
+	z.wz = pair(z.A, byte(addr+1))
+}
+
+// loadSpecial loads I or R into A, copying IFF2 to P/V
+func (z *Z80) loadSpecial(v byte) {
+	z.A = v
+	z.F = z.carry() | sz[v]
+	if z.IFF2 {
+		z.F |= FlagPV
+	}
+}
+
+// jumpRelativeIf fetches a displacement and adds it to PC if cond is true,
+// taking extra T-states
+func (z *Z80) jumpRelativeIf(cond bool, extra int) {
+	d := int8(z.fetch())
+	if cond {
+		z.PC += uint16(d)
+		z.wz = z.PC
+		z.cycles += extra
+	}
+}
+
+func (z *Z80) callIf(cond bool) {
+	nn := z.fetch16()
+	z.wz = nn
+	if cond {
+		z.push(z.PC)
+		z.PC = nn
+		z.cycles += 7
+	}
+}
+
+func (z *Z80) ret() {
+	z.PC = z.pop()
+	z.wz = z.PC
+}
+
+func (z *Z80) rst(vector uint16) {
+	z.push(z.PC)
+	z.PC = vector
+	z.wz = vector
+}
+
+// inC reads the port in BC for IN r, (C)
+func (z *Z80) inC() byte {
+	port := z.BC()
+	v := z.in(port)
+	z.F = z.carry() | szp[v]
+	z.wz = port + 1
+	return v
+}
+
+func (z *Z80) outC(v byte) {
+	port := z.BC()
+	z.out(port, v)
+	z.wz = port + 1
+}
+
+// repeatBlock moves PC back to the start of a repeating block instruction
+func (z *Z80) repeatBlock() {
+	z.PC -= 2
+	z.wz = z.PC + 1
+	z.cycles += 5
+}
+
+// blockLoad runs LDI, LDD, LDIR and LDDR. X and Y come from A plus the byte
+// copied.
+func (z *Z80) blockLoad(step uint16, repeat bool) {
+	v := z.read(z.HL())
+	z.write(z.DE(), v)
+	z.SetHL(z.HL() + step)
+	z.SetDE(z.DE() + step)
+	z.SetBC(z.BC() - 1)
+
+	n := v + z.A
+	z.F = z.F&(FlagS|FlagZ|FlagC) | n&FlagX | n<<4&FlagY
+	if z.BC() != 0 {
+		z.F |= FlagPV
+		if repeat {
+			z.repeatBlock()
+		}
+	}
+}
+
+// blockCompare runs CPI, CPD, CPIR and CPDR. X and Y come from the result
+// minus H.
+func (z *Z80) blockCompare(step uint16, repeat bool) {
+	v := z.read(z.HL())
+	r := z.A - v
+	h := (z.A ^ v ^ r) & FlagH
+	z.SetHL(z.HL() + step)
+	z.SetBC(z.BC() - 1)
+	z.wz += step
+
+	n := r - h>>4
+	z.F = z.carry() | FlagN | sz[r]&(FlagS|FlagZ) | h | n&FlagX | n<<4&FlagY
+	if z.BC() != 0 {
+		z.F |= FlagPV
+		if repeat && r != 0 {
+			z.repeatBlock()
+		}
+	}
+}
+
+// blockIn runs INI, IND, INIR and INDR
+func (z *Z80) blockIn(step uint16, repeat bool) {
+	v := z.in(z.BC())
+	z.wz = z.BC() + step
+	z.write(z.HL(), v)
+	z.B--
+	z.SetHL(z.HL() + step)
+
+	z.blockIOFlags(v, uint16(z.C+byte(step))+uint16(v))
+	if repeat && z.B != 0 {
+		z.repeatBlock()
+	}
+}
+
+// blockOut runs OUTI, OUTD, OTIR and OTDR
+func (z *Z80) blockOut(step uint16, repeat bool) {
+	v := z.read(z.HL())
+	z.B--
+	z.wz = z.BC() + step
+	z.out(z.BC(), v)
+	z.SetHL(z.HL() + step)
+
+	z.blockIOFlags(v, uint16(z.L)+uint16(v))
+	if repeat && z.B != 0 {
+		z.repeatBlock()
+	}
+}
+
+// blockIOFlags sets the flags of the block I/O instructions from the byte
+// transferred and k, an undocumented sum that depends on the instruction
+func (z *Z80) blockIOFlags(v byte, k uint16) {
+	z.F = sz[z.B] | v>>6&FlagN
+	if k > 0xFF {
+		z.F |= FlagH | FlagC
+	}
+	if parity(byte(k)&7 ^ z.B) {
+		z.F |= FlagPV
+	}
+}
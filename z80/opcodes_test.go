@@ -0,0 +1,145 @@
+package z80
+
+import (
+	"fmt"
+	"testing"
+)
+
+// notTakenCycles holds the documented T-states of the unprefixed opcodes. For
+// conditional instructions it's the cost when the condition is not met. The
+// prefixes are 0.
+var notTakenCycles = [256]int{
+	4, 10, 7, 6, 4, 4, 7, 4, 4, 11, 7, 6, 4, 4, 7, 4,
+	8, 10, 7, 6, 4, 4, 7, 4, 12, 11, 7, 6, 4, 4, 7, 4,
+	7, 10, 16, 6, 4, 4, 7, 4, 7, 11, 16, 6, 4, 4, 7, 4,
+	7, 10, 13, 6, 11, 11, 10, 4, 7, 11, 13, 6, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	7, 7, 7, 7, 7, 7, 4, 7, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	4, 4, 4, 4, 4, 4, 7, 4, 4, 4, 4, 4, 4, 4, 7, 4,
+	5, 10, 10, 10, 10, 11, 7, 11, 5, 10, 10, 0, 10, 17, 7, 11,
+	5, 10, 10, 11, 10, 11, 7, 11, 5, 4, 10, 11, 10, 0, 7, 11,
+	5, 10, 10, 19, 10, 11, 7, 11, 5, 4, 10, 4, 10, 0, 7, 11,
+	5, 10, 10, 4, 10, 11, 7, 11, 5, 6, 10, 4, 10, 0, 7, 11,
+}
+
+// condition returns the condition field of a conditional opcode: JR cc, RET
+// cc, JP cc and CALL cc
+func condition(op byte) (c byte, extra int, ok bool) {
+	switch {
+	case op&0xE7 == 0x20:
+		return op>>3&7 - 4, 5, true
+	case op&0xC7 == 0xC0:
+		return op >> 3 & 7, 6, true
+	case op&0xC7 == 0xC2:
+		return op >> 3 & 7, 0, true
+	case op&0xC7 == 0xC4:
+		return op >> 3 & 7, 7, true
+	}
+	return 0, 0, false
+}
+
+func TestOpcodeCycles(t *testing.T) {
+	run := func(t *testing.T, op, f byte, expected int) {
+		z, _ := newTestZ80([]byte{op, 0x00, 0x00})
+		z.F = f
+		z.B = 1
+
+		if cycles := z.Step(); cycles != expected {
+			t.Errorf("expected cycles=%d, got %d", expected, cycles)
+		}
+	}
+
+	for i, inst := range opcodes {
+		if inst.exec == nil {
+			continue
+		}
+
+		op, inst := byte(i), inst
+		t.Run(fmt.Sprintf("%02X %s", op, inst.name), func(t *testing.T) {
+			c, extra, conditional := condition(op)
+			if !conditional {
+				run(t, op, 0, notTakenCycles[op])
+				return
+			}
+
+			// even conditions (NZ, NC, PO, P) are false with every flag set
+			falseF := byte(0xFF)
+			if c&1 == 1 {
+				falseF = 0
+			}
+			run(t, op, falseF, notTakenCycles[op])
+			run(t, op, ^falseF, notTakenCycles[op]+extra)
+		})
+	}
+}
+
+func TestPrefixedCycles(t *testing.T) {
+	tbl := []struct {
+		name    string
+		program []byte
+		cycles  int
+	}{
+		{"RLC B", []byte{0xCB, 0x00}, 8},
+		{"BIT 0, (HL)", []byte{0xCB, 0x46}, 12},
+		{"SET 0, (HL)", []byte{0xCB, 0xC6}, 15},
+		{"IN B, (C)", []byte{0xED, 0x40}, 12},
+		{"LD (nn), BC", []byte{0xED, 0x43, 0x00, 0x00}, 20},
+		{"RETI", []byte{0xED, 0x4D}, 14},
+		{"IM 2", []byte{0xED, 0x5E}, 8},
+		{"LD R, A", []byte{0xED, 0x4F}, 9},
+		{"ED NOP", []byte{0xED, 0x00}, 8},
+		{"LD SP, IX", []byte{0xDD, 0xF9}, 10},
+		{"INC IY", []byte{0xFD, 0x23}, 10},
+		{"LD (nn), IX", []byte{0xDD, 0x22, 0x00, 0x00}, 20},
+		{"DD NOP", []byte{0xDD, 0x00}, 8},
+		{"DD DD NOP", []byte{0xDD, 0xDD, 0x00}, 12},
+		{"DD ED NEG", []byte{0xDD, 0xED, 0x44}, 12},
+		{"ADD A, (IY+d)", []byte{0xFD, 0x86, 0x00}, 19},
+		{"LD (IX+d), B", []byte{0xDD, 0x70, 0x00}, 19},
+	}
+
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z, _ := newTestZ80(tc.program)
+			z.SP = 0x8000
+
+			if cycles := z.Step(); cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, cycles)
+			}
+		})
+	}
+}
+
+func TestOpcodeNames(t *testing.T) {
+	tbl := []struct {
+		table    *[256]opcode
+		op       byte
+		expected string
+	}{
+		{&opcodes, 0x08, "EX AF, AF'"},
+		{&opcodes, 0x22, "LD (nn), HL"},
+		{&opcodes, 0x7E, "LD A, (HL)"},
+		{&opcodes, 0xB9, "CP A, C"},
+		{&opcodes, 0xE2, "JP PO, nn"},
+		{&opcodes, 0xFF, "RST 38H"},
+		{&cbOpcodes, 0x36, "SLL (HL)"},
+		{&cbOpcodes, 0x7F, "BIT 7, A"},
+		{&edOpcodes, 0x4D, "RETI"},
+		{&edOpcodes, 0x5E, "IM 2"},
+		{&edOpcodes, 0x70, "IN (C)"},
+		{&edOpcodes, 0x7A, "ADC HL, SP"},
+		{&edOpcodes, 0xB0, "LDIR"},
+		{&edOpcodes, 0xBB, "OTDR"},
+	}
+
+	for _, tc := range tbl {
+		if name := tc.table[tc.op].name; name != tc.expected {
+			t.Errorf("opcode %02X: expected %q, got %q", tc.op, tc.expected, name)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package z80
+
+// Registers is the main register set. The Z80 has two of them, exchanged by
+// EX AF, AF' and EXX.
+type Registers struct {
+	A, F, B, C, D, E, H, L byte
+}
+
+// Flags in F. X and Y are undocumented copies of bits 3 and 5 of a result.
+const (
+	FlagC byte = 1 << iota
+	FlagN
+	FlagPV
+	FlagX
+	FlagH
+	FlagY
+	FlagZ
+	FlagS
+)
+
+func (r *Registers) AF() uint16 {
+	return pair(r.A, r.F)
+}
+
+func (r *Registers) SetAF(v uint16) {
+	writePair(&r.A, &r.F, v)
+}
+
+func (r *Registers) BC() uint16 {
+	return pair(r.B, r.C)
+}
+
+func (r *Registers) SetBC(v uint16) {
+	writePair(&r.B, &r.C, v)
+}
+
+func (r *Registers) DE() uint16 {
+	return pair(r.D, r.E)
+}
+
+func (r *Registers) SetDE(v uint16) {
+	writePair(&r.D, &r.E, v)
+}
+
+func (r *Registers) HL() uint16 {
+	return pair(r.H, r.L)
+}
+
+func (r *Registers) SetHL(v uint16) {
+	writePair(&r.H, &r.L, v)
+}
+
+// exx exchanges BC, DE and HL with the shadow set
+func (z *Z80) exx() {
+	s := &z.Shadow
+	z.B, s.B = s.B, z.B
+	z.C, s.C = s.C, z.C
+	z.D, s.D = s.D, z.D
+	z.E, s.E = s.E, z.E
+	z.H, s.H = s.H, z.H
+	z.L, s.L = s.L, z.L
+}
+
+// exAF exchanges AF with the shadow AF'
+func (z *Z80) exAF() {
+	z.A, z.Shadow.A = z.Shadow.A, z.A
+	z.F, z.Shadow.F = z.Shadow.F, z.F
+}
+
+// regs returns register r of the B, C, D, E, H, L, (HL), A encoding, ignoring
+// any DD or FD prefix. r must not be 6.
+func (z *Z80) regs(r byte) *byte {
+	switch r {
+	case 0:
+		return &z.B
+	case 1:
+		return &z.C
+	case 2:
+		return &z.D
+	case 3:
+		return &z.E
+	case 4:
+		return &z.H
+	case 5:
+		return &z.L
+	case 7:
+		return &z.A
+	}
+	panic("z80: (HL) is not a register")
+}
+
+// reg returns register r. After a DD or FD prefix, H and L are the halves of
+// IX or IY.
+func (z *Z80) reg(r byte) byte {
+	if z.index != nil {
+		switch r {
+		case 4:
+			return byte(*z.index >> 8)
+		case 5:
+			return byte(*z.index)
+		}
+	}
+	return *z.regs(r)
+}
+
+func (z *Z80) setReg(r byte, v byte) {
+	if z.index != nil {
+		switch r {
+		case 4:
+			*z.index = *z.index&0x00FF | uint16(v)<<8
+			return
+		case 5:
+			*z.index = *z.index&0xFF00 | uint16(v)
+			return
+		}
+	}
+	*z.regs(r) = v
+}
+
+// indexedHL returns HL, or IX or IY after a DD or FD prefix
+func (z *Z80) indexedHL() uint16 {
+	if z.index != nil {
+		return *z.index
+	}
+	return z.HL()
+}
+
+func (z *Z80) setIndexedHL(v uint16) {
+	if z.index != nil {
+		*z.index = v
+		return
+	}
+	z.SetHL(v)
+}
+
+// addr returns the address of the (HL) operand. After a DD or FD prefix the
+// operand is (IX+d) or (IY+d) and the displacement is fetched.
+func (z *Z80) addr() uint16 {
+	if z.index == nil {
+		return z.HL()
+	}
+
+	d := int8(z.fetch())
+	z.cycles += 8 // displacement fetch and address calculation
+	z.wz = *z.index + uint16(d)
+	return z.wz
+}
+
+// rp returns register pair p of the BC, DE, HL, SP encoding
+func (z *Z80) rp(p byte) uint16 {
+	switch p {
+	case 0:
+		return z.BC()
+	case 1:
+		return z.DE()
+	case 2:
+		return z.indexedHL()
+	}
+	return z.SP
+}
+
+func (z *Z80) setRP(p byte, v uint16) {
+	switch p {
+	case 0:
+		z.SetBC(v)
+	case 1:
+		z.SetDE(v)
+	case 2:
+		z.setIndexedHL(v)
+	default:
+		z.SP = v
+	}
+}
+
+// rp2 returns register pair p of the BC, DE, HL, AF encoding used by PUSH
+// and POP
+func (z *Z80) rp2(p byte) uint16 {
+	if p == 3 {
+		return z.AF()
+	}
+	return z.rp(p)
+}
+
+func (z *Z80) setRP2(p byte, v uint16) {
+	if p == 3 {
+		z.SetAF(v)
+		return
+	}
+	z.setRP(p, v)
+}
+
+// cond evaluates condition c of the NZ, Z, NC, C, PO, PE, P, M encoding
+func (z *Z80) cond(c byte) bool {
+	var flag byte
+	switch c >> 1 {
+	case 0:
+		flag = FlagZ
+	case 1:
+		flag = FlagC
+	case 2:
+		flag = FlagPV
+	case 3:
+		flag = FlagS
+	}
+	return (z.F&flag != 0) == (c&1 == 1)
+}
+
+func pair(hi, lo byte) uint16 {
+	return uint16(hi)*0x100 + uint16(lo)
+}
+
+func writePair(hi, lo *byte, v uint16) {
+	*hi = byte(v / 0x100)
+	*lo = byte(v % 0x100)
+}
+
+func split(v uint16) (hi, lo byte) {
+	hi = byte(v / 0x100)
+	lo = byte(v % 0x100)
+	return
+}
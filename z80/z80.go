@@ -0,0 +1,214 @@
+// Package z80 implements the Zilog Z80 CPU: the complete documented
+// instruction set including the CB, ED, DD and FD prefixes, IX/IY, the shadow
+// register set, the I and R registers, interrupt modes 0, 1 and 2, NMI and the
+// undocumented X and Y flags. The Game Boy SM83 core lives in the cpu package;
+// both run against a memory.Bus.
+package z80
+
+import "github.com/danicat/gogoboy/memory"
+
+// IO is the port address space accessed by IN and OUT. As on the real chip
+// the high byte of the port comes from A or B, depending on the instruction.
+type IO interface {
+	In(port uint16) byte
+	Out(port uint16, val byte)
+}
+
+// Z80 holds the internal representation of the Z80 CPU registers
+type Z80 struct {
+	Registers
+	Shadow         Registers // AF', BC', DE' and HL'
+	IX, IY, SP, PC uint16
+	I, R           byte
+	IFF1, IFF2     bool
+	IM             byte
+	wz             uint16 // the internal MEMPTR register, seen through the X and Y flags
+	bus            memory.Bus
+	io             IO
+	cycles         int
+	halted         bool
+	index          *uint16 // IX or IY after a DD or FD prefix, nil otherwise
+	eiDelay        bool
+	nmi            bool
+	irq            bool
+	irqData        byte
+}
+
+// New creates a Z80 attached to bus, in its reset state
+func New(bus memory.Bus) *Z80 {
+	z := &Z80{bus: bus}
+	z.Reset()
+	return z
+}
+
+// SetIO attaches the I/O port space. Without one, IN reads 0xFF and OUT is
+// ignored.
+func (z *Z80) SetIO(io IO) {
+	z.io = io
+}
+
+// Reset puts the CPU in its power-on state. The registers the Z80 leaves
+// undefined keep their values, except AF and SP which are set to 0xFFFF.
+func (z *Z80) Reset() {
+	z.PC = 0
+	z.SP = 0xFFFF
+	z.SetAF(0xFFFF)
+	z.I = 0
+	z.R = 0
+	z.IFF1 = false
+	z.IFF2 = false
+	z.IM = 0
+	z.wz = 0
+	z.halted = false
+	z.index = nil
+	z.eiDelay = false
+	z.nmi = false
+	z.irq = false
+	z.cycles = 0
+}
+
+// Cycles returns the number of T-states elapsed since the last reset
+func (z *Z80) Cycles() int {
+	return z.cycles
+}
+
+// Halted reports whether the CPU is waiting for an interrupt after HALT
+func (z *Z80) Halted() bool {
+	return z.halted
+}
+
+// Step runs one instruction, or services a pending interrupt, and returns the
+// number of T-states it took
+func (z *Z80) Step() int {
+	start := z.cycles
+	z.step()
+	return z.cycles - start
+}
+
+// Run executes instructions until at least cycles T-states have passed and
+// returns the number of T-states used
+func (z *Z80) Run(cycles int) int {
+	start := z.cycles
+	for z.cycles-start < cycles {
+		z.step()
+	}
+	return z.cycles - start
+}
+
+func (z *Z80) step() {
+	if z.interrupt() {
+		return
+	}
+
+	// a halted CPU keeps executing NOPs, which refresh memory
+	if z.halted {
+		z.refresh()
+		z.cycles += 4
+		return
+	}
+
+	op := z.fetchOpcode()
+	for op == 0xDD || op == 0xFD {
+		z.index = &z.IX
+		if op == 0xFD {
+			z.index = &z.IY
+		}
+		z.cycles += 4
+		op = z.fetchOpcode()
+	}
+
+	var inst *opcode
+	switch {
+	case op == 0xCB && z.index != nil:
+		z.indexedCB()
+		z.index = nil
+		return
+	case op == 0xCB:
+		inst = &cbOpcodes[z.fetchOpcode()]
+	case op == 0xED:
+		// ED instructions ignore a DD or FD prefix
+		z.index = nil
+		inst = &edOpcodes[z.fetchOpcode()]
+	default:
+		inst = &opcodes[op]
+	}
+
+	z.cycles += inst.cycles
+	inst.exec(z)
+	z.index = nil
+}
+
+// refresh increments the lower 7 bits of R, as every opcode fetch does
+func (z *Z80) refresh() {
+	z.R = z.R&0x80 | (z.R+1)&0x7F
+}
+
+// fetchOpcode reads an opcode or prefix byte at PC
+func (z *Z80) fetchOpcode() byte {
+	z.refresh()
+	return z.fetch()
+}
+
+func (z *Z80) fetch() byte {
+	v := z.bus.Read(z.PC)
+	z.PC++
+	return v
+}
+
+// fetch16 reads a little-endian 16-bit immediate
+func (z *Z80) fetch16() uint16 {
+	lo := z.fetch()
+	hi := z.fetch()
+	return pair(hi, lo)
+}
+
+func (z *Z80) read(addr uint16) byte {
+	return z.bus.Read(addr)
+}
+
+func (z *Z80) write(addr uint16, val byte) {
+	z.bus.Write(addr, val)
+}
+
+// read16 reads a little-endian 16-bit value
+func (z *Z80) read16(addr uint16) uint16 {
+	lo := z.read(addr)
+	hi := z.read(addr + 1)
+	return pair(hi, lo)
+}
+
+// write16 writes a little-endian 16-bit value, low byte first
+func (z *Z80) write16(addr uint16, val uint16) {
+	hi, lo := split(val)
+	z.write(addr, lo)
+	z.write(addr+1, hi)
+}
+
+func (z *Z80) push(v uint16) {
+	hi, lo := split(v)
+	z.SP--
+	z.write(z.SP, hi)
+	z.SP--
+	z.write(z.SP, lo)
+}
+
+func (z *Z80) pop() uint16 {
+	lo := z.read(z.SP)
+	z.SP++
+	hi := z.read(z.SP)
+	z.SP++
+	return pair(hi, lo)
+}
+
+func (z *Z80) in(port uint16) byte {
+	if z.io == nil {
+		return 0xFF
+	}
+	return z.io.In(port)
+}
+
+func (z *Z80) out(port uint16, val byte) {
+	if z.io != nil {
+		z.io.Out(port, val)
+	}
+}
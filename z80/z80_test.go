@@ -0,0 +1,659 @@
+package z80
+
+import (
+	"testing"
+
+	"github.com/danicat/gogoboy/memory"
+)
+
+type testcase struct {
+	name     string
+	program  []byte
+	input    Z80
+	memory   map[uint16]byte
+	steps    int
+	expected Z80
+	written  map[uint16]byte
+	pc       uint16
+	cycles   int
+}
+
+// newTestZ80 creates a Z80 with program loaded at address 0
+func newTestZ80(program []byte) (*Z80, *memory.Memory) {
+	m := memory.NewMemory()
+	m.LoadProgram(program, 0)
+	return New(m), m
+}
+
+func runTestcases(t *testing.T, tbl []testcase) {
+	for _, tc := range tbl {
+		t.Run(tc.name, func(t *testing.T) {
+			z, m := newTestZ80(tc.program)
+			z.Registers = tc.input.Registers
+			z.Shadow = tc.input.Shadow
+			z.IX, z.IY, z.SP, z.I = tc.input.IX, tc.input.IY, tc.input.SP, tc.input.I
+			z.IFF2 = tc.input.IFF2
+			for addr, v := range tc.memory {
+				m.Write(addr, v)
+			}
+
+			steps := tc.steps
+			if steps == 0 {
+				steps = 1
+			}
+			cycles := 0
+			for i := 0; i < steps; i++ {
+				cycles += z.Step()
+			}
+
+			if z.Registers != tc.expected.Registers {
+				t.Errorf("expected registers %+v, got %+v", tc.expected.Registers, z.Registers)
+			}
+			if z.Shadow != tc.expected.Shadow {
+				t.Errorf("expected shadow registers %+v, got %+v", tc.expected.Shadow, z.Shadow)
+			}
+			if z.IX != tc.expected.IX || z.IY != tc.expected.IY || z.SP != tc.expected.SP {
+				t.Errorf("expected IX=%04x IY=%04x SP=%04x, got IX=%04x IY=%04x SP=%04x",
+					tc.expected.IX, tc.expected.IY, tc.expected.SP, z.IX, z.IY, z.SP)
+			}
+			for addr, v := range tc.written {
+				if got := m.Read(addr); got != v {
+					t.Errorf("expected %02x at %04x, got %02x", v, addr, got)
+				}
+			}
+			if z.PC != tc.pc {
+				t.Errorf("expected PC=%04x, got %04x", tc.pc, z.PC)
+			}
+			if cycles != tc.cycles {
+				t.Errorf("expected cycles=%d, got %d", tc.cycles, cycles)
+			}
+		})
+	}
+}
+
+func TestLoads(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "LD A, n",
+			program:  []byte{0x3E, 0x42},
+			expected: Z80{Registers: Registers{A: 0x42}},
+			pc:       2,
+			cycles:   7,
+		},
+		{
+			name:     "LD (nn), A",
+			program:  []byte{0x32, 0x00, 0x20},
+			input:    Z80{Registers: Registers{A: 0x42}},
+			expected: Z80{Registers: Registers{A: 0x42}},
+			written:  map[uint16]byte{0x2000: 0x42},
+			pc:       3,
+			cycles:   13,
+		},
+		{
+			name:     "LD HL, (nn) ED prefixed",
+			program:  []byte{0xED, 0x6B, 0x00, 0x20},
+			memory:   map[uint16]byte{0x2000: 0xEF, 0x2001: 0xBE},
+			expected: Z80{Registers: Registers{H: 0xBE, L: 0xEF}},
+			pc:       4,
+			cycles:   20,
+		},
+		{
+			name:     "LD A, (IX+d)",
+			program:  []byte{0xDD, 0x7E, 0x05},
+			input:    Z80{IX: 0x1000},
+			memory:   map[uint16]byte{0x1005: 0x42},
+			expected: Z80{Registers: Registers{A: 0x42}, IX: 0x1000},
+			pc:       3,
+			cycles:   19,
+		},
+		{
+			name:     "LD (IY+d), n with negative displacement",
+			program:  []byte{0xFD, 0x36, 0xFF, 0x99},
+			input:    Z80{IY: 0x1000},
+			expected: Z80{IY: 0x1000},
+			written:  map[uint16]byte{0x0FFF: 0x99},
+			pc:       4,
+			cycles:   19,
+		},
+		{
+			name:     "LD H, (IX+d) loads H",
+			program:  []byte{0xDD, 0x66, 0x02},
+			input:    Z80{IX: 0x1000},
+			memory:   map[uint16]byte{0x1002: 0x55},
+			expected: Z80{Registers: Registers{H: 0x55}, IX: 0x1000},
+			pc:       3,
+			cycles:   19,
+		},
+		{
+			name:     "LD IXH, n",
+			program:  []byte{0xDD, 0x26, 0x12},
+			input:    Z80{IX: 0x1034},
+			expected: Z80{IX: 0x1234},
+			pc:       3,
+			cycles:   11,
+		},
+		{
+			name:     "LD IX, nn",
+			program:  []byte{0xDD, 0x21, 0x34, 0x12},
+			expected: Z80{IX: 0x1234},
+			pc:       4,
+			cycles:   14,
+		},
+		{
+			name:     "PUSH IX",
+			program:  []byte{0xDD, 0xE5},
+			input:    Z80{IX: 0xBEEF, SP: 0x8000},
+			expected: Z80{IX: 0xBEEF, SP: 0x7FFE},
+			written:  map[uint16]byte{0x7FFE: 0xEF, 0x7FFF: 0xBE},
+			pc:       2,
+			cycles:   15,
+		},
+		{
+			name:     "EX (SP), IY",
+			program:  []byte{0xFD, 0xE3},
+			input:    Z80{IY: 0x1234, SP: 0x8000},
+			memory:   map[uint16]byte{0x8000: 0xEF, 0x8001: 0xBE},
+			expected: Z80{IY: 0xBEEF, SP: 0x8000},
+			written:  map[uint16]byte{0x8000: 0x34, 0x8001: 0x12},
+			pc:       2,
+			cycles:   23,
+		},
+		{
+			name:     "EX AF, AF' and EXX",
+			program:  []byte{0x08, 0xD9},
+			input:    Z80{Registers: Registers{1, 2, 3, 4, 5, 6, 7, 8}, Shadow: Registers{9, 10, 11, 12, 13, 14, 15, 16}},
+			steps:    2,
+			expected: Z80{Registers: Registers{9, 10, 11, 12, 13, 14, 15, 16}, Shadow: Registers{1, 2, 3, 4, 5, 6, 7, 8}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "EX DE, HL ignores the prefix",
+			program:  []byte{0xDD, 0xEB},
+			input:    Z80{Registers: Registers{D: 1, E: 2, H: 3, L: 4}, IX: 0x5678},
+			expected: Z80{Registers: Registers{D: 3, E: 4, H: 1, L: 2}, IX: 0x5678},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "LD A, I copies IFF2 to P/V",
+			program:  []byte{0xED, 0x57},
+			input:    Z80{IFF2: true},
+			expected: Z80{Registers: Registers{F: FlagZ | FlagPV}},
+			pc:       2,
+			cycles:   9,
+		},
+		{
+			name:     "LDIR",
+			program:  []byte{0xED, 0xB0},
+			input:    Z80{Registers: Registers{B: 0, C: 3, D: 0x30, H: 0x20}},
+			memory:   map[uint16]byte{0x2000: 1, 0x2001: 2, 0x2002: 3},
+			steps:    3,
+			expected: Z80{Registers: Registers{D: 0x30, E: 0x03, H: 0x20, L: 0x03, F: FlagY}},
+			written:  map[uint16]byte{0x3000: 1, 0x3001: 2, 0x3002: 3},
+			pc:       2,
+			cycles:   21 + 21 + 16,
+		},
+		{
+			name:     "LDI undocumented flags",
+			program:  []byte{0xED, 0xA0},
+			input:    Z80{Registers: Registers{C: 1, D: 0x30, H: 0x20}},
+			memory:   map[uint16]byte{0x2000: 0x0A},
+			expected: Z80{Registers: Registers{D: 0x30, E: 0x01, H: 0x20, L: 0x01, F: FlagX | FlagY}},
+			written:  map[uint16]byte{0x3000: 0x0A},
+			pc:       2,
+			cycles:   16,
+		},
+		{
+			name:     "CPIR",
+			program:  []byte{0xED, 0xB1},
+			input:    Z80{Registers: Registers{A: 0x02, C: 5, H: 0x20}},
+			memory:   map[uint16]byte{0x2000: 1, 0x2001: 2, 0x2002: 3},
+			steps:    2,
+			expected: Z80{Registers: Registers{A: 0x02, C: 3, H: 0x20, L: 0x02, F: FlagZ | FlagPV | FlagN}},
+			pc:       2,
+			cycles:   21 + 16,
+		},
+	}
+
+	runTestcases(t, tbl)
+}
+
+func TestArithmetic(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "ADD A, B overflow",
+			program:  []byte{0x80},
+			input:    Z80{Registers: Registers{A: 0x7F, B: 0x01}},
+			expected: Z80{Registers: Registers{A: 0x80, B: 0x01, F: FlagS | FlagH | FlagPV}},
+			pc:       1,
+			cycles:   4,
+		},
+		{
+			name:     "SUB A, B overflow",
+			program:  []byte{0x90},
+			input:    Z80{Registers: Registers{A: 0x80, B: 0x01}},
+			expected: Z80{Registers: Registers{A: 0x7F, B: 0x01, F: FlagY | FlagH | FlagX | FlagPV | FlagN}},
+			pc:       1,
+			cycles:   4,
+		},
+		{
+			name:     "CP A, n takes X and Y from the operand",
+			program:  []byte{0xFE, 0x28},
+			expected: Z80{Registers: Registers{F: FlagS | FlagY | FlagH | FlagX | FlagN | FlagC}},
+			pc:       2,
+			cycles:   7,
+		},
+		{
+			name:     "INC A keeps carry",
+			program:  []byte{0x3C},
+			input:    Z80{Registers: Registers{A: 0x7F, F: FlagC}},
+			expected: Z80{Registers: Registers{A: 0x80, F: FlagS | FlagH | FlagPV | FlagC}},
+			pc:       1,
+			cycles:   4,
+		},
+		{
+			name:     "DEC B half borrow",
+			program:  []byte{0x05},
+			input:    Z80{Registers: Registers{B: 0x10}},
+			expected: Z80{Registers: Registers{B: 0x0F, F: FlagH | FlagX | FlagN}},
+			pc:       1,
+			cycles:   4,
+		},
+		{
+			name:     "INC (IX+d)",
+			program:  []byte{0xDD, 0x34, 0x01},
+			input:    Z80{IX: 0x2000},
+			memory:   map[uint16]byte{0x2001: 0x41},
+			expected: Z80{IX: 0x2000},
+			written:  map[uint16]byte{0x2001: 0x42},
+			pc:       3,
+			cycles:   23,
+		},
+		{
+			name:     "DAA after ADD",
+			program:  []byte{0x80, 0x27},
+			input:    Z80{Registers: Registers{A: 0x15, B: 0x27}},
+			steps:    2,
+			expected: Z80{Registers: Registers{A: 0x42, B: 0x27, F: FlagH | FlagPV}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "DAA after SUB",
+			program:  []byte{0x90, 0x27},
+			input:    Z80{Registers: Registers{A: 0x42, B: 0x15}},
+			steps:    2,
+			expected: Z80{Registers: Registers{A: 0x27, B: 0x15, F: FlagY | FlagPV | FlagN}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "NEG",
+			program:  []byte{0xED, 0x44},
+			input:    Z80{Registers: Registers{A: 0x80}},
+			expected: Z80{Registers: Registers{A: 0x80, F: FlagS | FlagPV | FlagN | FlagC}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "ADD A, IXL",
+			program:  []byte{0xDD, 0x85},
+			input:    Z80{Registers: Registers{A: 0x01}, IX: 0x1234},
+			expected: Z80{Registers: Registers{A: 0x35, F: FlagY}, IX: 0x1234},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "ADD HL, BC keeps S Z and P/V",
+			program:  []byte{0x09},
+			input:    Z80{Registers: Registers{B: 0x00, C: 0x01, H: 0x0F, L: 0xFF, F: FlagS | FlagZ | FlagPV}},
+			expected: Z80{Registers: Registers{B: 0x00, C: 0x01, H: 0x10, L: 0x00, F: FlagS | FlagZ | FlagH | FlagPV}},
+			pc:       1,
+			cycles:   11,
+		},
+		{
+			name:     "ADD IX, IX",
+			program:  []byte{0xDD, 0x29},
+			input:    Z80{IX: 0x8000},
+			expected: Z80{Registers: Registers{F: FlagC}},
+			pc:       2,
+			cycles:   15,
+		},
+		{
+			name:     "ADC HL, DE overflow",
+			program:  []byte{0xED, 0x5A},
+			input:    Z80{Registers: Registers{E: 0x01, H: 0x7F, L: 0xFF}},
+			expected: Z80{Registers: Registers{E: 0x01, H: 0x80, F: FlagS | FlagH | FlagPV}},
+			pc:       2,
+			cycles:   15,
+		},
+		{
+			name:     "SBC HL, DE borrow",
+			program:  []byte{0xED, 0x52},
+			input:    Z80{Registers: Registers{E: 0x01}},
+			expected: Z80{Registers: Registers{E: 0x01, H: 0xFF, L: 0xFF, F: FlagS | FlagY | FlagH | FlagX | FlagN | FlagC}},
+			pc:       2,
+			cycles:   15,
+		},
+		{
+			name:     "RRD",
+			program:  []byte{0xED, 0x67},
+			input:    Z80{Registers: Registers{A: 0x84, H: 0x20}},
+			memory:   map[uint16]byte{0x2000: 0x20},
+			expected: Z80{Registers: Registers{A: 0x80, H: 0x20, F: FlagS}},
+			written:  map[uint16]byte{0x2000: 0x42},
+			pc:       2,
+			cycles:   18,
+		},
+	}
+
+	runTestcases(t, tbl)
+}
+
+func TestRotatesAndBits(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:     "RLCA keeps S Z and P/V",
+			program:  []byte{0x07},
+			input:    Z80{Registers: Registers{A: 0x81, F: FlagS | FlagZ | FlagPV}},
+			expected: Z80{Registers: Registers{A: 0x03, F: FlagS | FlagZ | FlagPV | FlagC}},
+			pc:       1,
+			cycles:   4,
+		},
+		{
+			name:     "RL B",
+			program:  []byte{0xCB, 0x10},
+			input:    Z80{Registers: Registers{B: 0x80}},
+			expected: Z80{Registers: Registers{F: FlagZ | FlagPV | FlagC}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "SLL C",
+			program:  []byte{0xCB, 0x31},
+			input:    Z80{Registers: Registers{C: 0x80}},
+			expected: Z80{Registers: Registers{C: 0x01, F: FlagC}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "RLC (HL)",
+			program:  []byte{0xCB, 0x06},
+			input:    Z80{Registers: Registers{H: 0x20}},
+			memory:   map[uint16]byte{0x2000: 0x80},
+			expected: Z80{Registers: Registers{H: 0x20, F: FlagC}},
+			written:  map[uint16]byte{0x2000: 0x01},
+			pc:       2,
+			cycles:   15,
+		},
+		{
+			name:     "BIT 7, A",
+			program:  []byte{0xCB, 0x7F},
+			input:    Z80{Registers: Registers{A: 0x80, F: FlagC}},
+			expected: Z80{Registers: Registers{A: 0x80, F: FlagS | FlagH | FlagC}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "BIT 3, A takes X and Y from the operand",
+			program:  []byte{0xCB, 0x5F},
+			input:    Z80{Registers: Registers{A: 0x28}},
+			expected: Z80{Registers: Registers{A: 0x28, F: FlagY | FlagH | FlagX}},
+			pc:       2,
+			cycles:   8,
+		},
+		{
+			name:     "BIT 0, (IX+d) takes X and Y from the address",
+			program:  []byte{0xDD, 0xCB, 0x02, 0x46},
+			input:    Z80{IX: 0x2800},
+			expected: Z80{Registers: Registers{F: FlagZ | FlagY | FlagH | FlagX | FlagPV}, IX: 0x2800},
+			pc:       4,
+			cycles:   20,
+		},
+		{
+			name:     "SET 0, (IX+d) copies the result to B",
+			program:  []byte{0xDD, 0xCB, 0x02, 0xC0},
+			input:    Z80{IX: 0x1000},
+			expected: Z80{Registers: Registers{B: 0x01}, IX: 0x1000},
+			written:  map[uint16]byte{0x1002: 0x01},
+			pc:       4,
+			cycles:   23,
+		},
+		{
+			name:     "RR (IY+d)",
+			program:  []byte{0xFD, 0xCB, 0xFE, 0x1E},
+			input:    Z80{IY: 0x1002},
+			memory:   map[uint16]byte{0x1000: 0x01},
+			expected: Z80{Registers: Registers{F: FlagZ | FlagPV | FlagC}, IY: 0x1002},
+			written:  map[uint16]byte{0x1000: 0x00},
+			pc:       4,
+			cycles:   23,
+		},
+	}
+
+	runTestcases(t, tbl)
+}
+
+func TestControl(t *testing.T) {
+	tbl := []testcase{
+		{
+			name:    "JR NZ, e taken",
+			program: []byte{0x20, 0x05},
+			pc:      7,
+			cycles:  12,
+		},
+		{
+			name:     "JR NZ, e not taken",
+			program:  []byte{0x20, 0x05},
+			input:    Z80{Registers: Registers{F: FlagZ}},
+			expected: Z80{Registers: Registers{F: FlagZ}},
+			pc:       2,
+			cycles:   7,
+		},
+		{
+			name:     "DJNZ e",
+			program:  []byte{0x10, 0xFE},
+			input:    Z80{Registers: Registers{B: 2}},
+			expected: Z80{Registers: Registers{B: 1}},
+			pc:       0,
+			cycles:   13,
+		},
+		{
+			name:     "JP PE, nn",
+			program:  []byte{0xEA, 0x34, 0x12},
+			input:    Z80{Registers: Registers{F: FlagPV}},
+			expected: Z80{Registers: Registers{F: FlagPV}},
+			pc:       0x1234,
+			cycles:   10,
+		},
+		{
+			name:    "CALL M, nn not taken",
+			program: []byte{0xFC, 0x34, 0x12},
+			pc:      3,
+			cycles:  10,
+		},
+		{
+			name:     "CALL nn",
+			program:  []byte{0xCD, 0x34, 0x12},
+			input:    Z80{SP: 0x8000},
+			expected: Z80{SP: 0x7FFE},
+			written:  map[uint16]byte{0x7FFE: 0x03, 0x7FFF: 0x00},
+			pc:       0x1234,
+			cycles:   17,
+		},
+		{
+			name:     "RET PO",
+			program:  []byte{0xE0},
+			input:    Z80{SP: 0x8000},
+			memory:   map[uint16]byte{0x8000: 0x34, 0x8001: 0x12},
+			expected: Z80{SP: 0x8002},
+			pc:       0x1234,
+			cycles:   11,
+		},
+		{
+			name:     "JP (IY)",
+			program:  []byte{0xFD, 0xE9},
+			input:    Z80{IY: 0x4000},
+			expected: Z80{IY: 0x4000},
+			pc:       0x4000,
+			cycles:   8,
+		},
+		{
+			name:     "RST 38H",
+			program:  []byte{0xFF},
+			input:    Z80{SP: 0x8000},
+			expected: Z80{SP: 0x7FFE},
+			written:  map[uint16]byte{0x7FFE: 0x01},
+			pc:       0x0038,
+			cycles:   11,
+		},
+		{
+			name:    "HALT",
+			program: []byte{0x76},
+			steps:   3,
+			pc:      1,
+			cycles:  12,
+		},
+	}
+
+	runTestcases(t, tbl)
+}
+
+func TestRefresh(t *testing.T) {
+	// NOP, LD IX, nn, SET 0, (IX+0), LD A, I: every opcode fetch counts, but
+	// the displacement and the opcode of DDCB instructions don't
+	z, _ := newTestZ80([]byte{0x00, 0xDD, 0x21, 0x00, 0x20, 0xDD, 0xCB, 0x00, 0xC6, 0xED, 0x57})
+	z.R = 0xFF
+
+	for i := 0; i < 4; i++ {
+		z.Step()
+	}
+
+	// bit 7 is kept
+	if expected := byte(0x86); z.R != expected {
+		t.Errorf("expected R=%02x, got %02x", expected, z.R)
+	}
+}
+
+type testIO struct {
+	in  map[uint16]byte
+	out []uint16
+	val []byte
+}
+
+func (io *testIO) In(port uint16) byte {
+	return io.in[port]
+}
+
+func (io *testIO) Out(port uint16, val byte) {
+	io.out = append(io.out, port)
+	io.val = append(io.val, val)
+}
+
+func TestIO(t *testing.T) {
+	t.Run("IN A, (n)", func(t *testing.T) {
+		z, _ := newTestZ80([]byte{0xDB, 0xFE})
+		io := &testIO{in: map[uint16]byte{0x12FE: 0x42}}
+		z.SetIO(io)
+		z.A = 0x12
+
+		if cycles := z.Step(); cycles != 11 {
+			t.Errorf("expected cycles=%d, got %d", 11, cycles)
+		}
+		if z.A != 0x42 {
+			t.Errorf("expected A=%02x, got %02x", 0x42, z.A)
+		}
+	})
+
+	t.Run("IN B, (C)", func(t *testing.T) {
+		z, _ := newTestZ80([]byte{0xED, 0x40})
+		z.SetIO(&testIO{in: map[uint16]byte{0x0110: 0x00}})
+		z.SetBC(0x0110)
+		z.F = FlagC
+
+		if cycles := z.Step(); cycles != 12 {
+			t.Errorf("expected cycles=%d, got %d", 12, cycles)
+		}
+		if z.B != 0 || z.F != FlagZ|FlagPV|FlagC {
+			t.Errorf("expected B=00 F=%08b, got B=%02x F=%08b", FlagZ|FlagPV|FlagC, z.B, z.F)
+		}
+	})
+
+	t.Run("OTIR", func(t *testing.T) {
+		z, m := newTestZ80([]byte{0xED, 0xB3})
+		io := &testIO{}
+		z.SetIO(io)
+		z.SetBC(0x0210)
+		z.SetHL(0x2000)
+		m.Write(0x2000, 0xAA)
+		m.Write(0x2001, 0xBB)
+
+		cycles := z.Step() + z.Step()
+		if cycles != 21+16 {
+			t.Errorf("expected cycles=%d, got %d", 21+16, cycles)
+		}
+
+		// B is decremented before the port is written
+		expectedPorts := []uint16{0x0110, 0x0010}
+		expectedVals := []byte{0xAA, 0xBB}
+		for i := range expectedPorts {
+			if i >= len(io.out) || io.out[i] != expectedPorts[i] || io.val[i] != expectedVals[i] {
+				t.Fatalf("expected writes %04x %02x, got %04x %02x", expectedPorts, expectedVals, io.out, io.val)
+			}
+		}
+		if z.B != 0 || z.F&FlagZ == 0 {
+			t.Errorf("expected B=0 and Z set, got B=%02x F=%08b", z.B, z.F)
+		}
+	})
+
+	t.Run("no IO attached", func(t *testing.T) {
+		z, _ := newTestZ80([]byte{0xDB, 0x00, 0xD3, 0x00})
+		z.A = 0
+		z.Step()
+		z.Step()
+		if z.A != 0xFF {
+			t.Errorf("expected A=ff, got %02x", z.A)
+		}
+	})
+}
+
+func TestReset(t *testing.T) {
+	z, _ := newTestZ80(nil)
+	z.PC, z.I, z.R, z.IM, z.IFF1 = 0x1234, 1, 2, 2, true
+	z.Step()
+	z.Reset()
+
+	if z.PC != 0 || z.I != 0 || z.R != 0 || z.IM != 0 || z.IFF1 || z.IFF2 {
+		t.Errorf("expected reset state, got PC=%04x I=%02x R=%02x IM=%d IFF1=%v IFF2=%v", z.PC, z.I, z.R, z.IM, z.IFF1, z.IFF2)
+	}
+	if z.AF() != 0xFFFF || z.SP != 0xFFFF {
+		t.Errorf("expected AF=ffff SP=ffff, got AF=%04x SP=%04x", z.AF(), z.SP)
+	}
+	if z.Cycles() != 0 {
+		t.Errorf("expected cycles=0, got %d", z.Cycles())
+	}
+}
+
+func TestRun(t *testing.T) {
+	// multiply 6 by 7 by repeated addition
+	program := []byte{
+		0x3E, 0x00, // LD A, 0
+		0x06, 0x07, // LD B, 7
+		0xC6, 0x06, // loop: ADD A, 6
+		0x10, 0xFC, // DJNZ loop
+		0x76, // HALT
+	}
+	z, _ := newTestZ80(program)
+
+	cycles := z.Run(1000)
+	if cycles < 1000 {
+		t.Errorf("expected at least 1000 cycles, got %d", cycles)
+	}
+	if !z.Halted() {
+		t.Errorf("expected the CPU to be halted")
+	}
+	if z.A != 42 {
+		t.Errorf("expected A=%d, got %d", 42, z.A)
+	}
+}